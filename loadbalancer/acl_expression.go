@@ -0,0 +1,404 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+// aclExpressionError is returned when an ACL expression can't be parsed, and identifies the
+// token responsible by its column within the expression
+type aclExpressionError struct {
+	Column int
+	Token  string
+	Msg    string
+}
+
+func (e *aclExpressionError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%s at column %d", e.Msg, e.Column)
+	}
+
+	return fmt.Sprintf("%s at column %d: %q", e.Msg, e.Column, e.Token)
+}
+
+type aclExpressionToken struct {
+	value  string
+	column int
+	quoted bool
+}
+
+// tokenizeACLExpression splits an expression into whitespace separated tokens. Tokens may be
+// quoted with single or double quotes to include whitespace, and ';' is always a token of its own
+func tokenizeACLExpression(expression string) ([]aclExpressionToken, error) {
+	var tokens []aclExpressionToken
+
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == ';':
+			tokens = append(tokens, aclExpressionToken{value: ";", column: i + 1})
+			i++
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var value strings.Builder
+			for i < len(runes) && runes[i] != r {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			if i >= len(runes) {
+				return nil, &aclExpressionError{Column: start + 1, Token: string(runes[start:]), Msg: "unterminated quoted string"}
+			}
+			i++
+			tokens = append(tokens, aclExpressionToken{value: value.String(), column: start + 1, quoted: true})
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune(" \t\n\r;", runes[i]) {
+				i++
+			}
+			tokens = append(tokens, aclExpressionToken{value: string(runes[start:i]), column: start + 1})
+		}
+	}
+
+	return tokens, nil
+}
+
+// parseACLExpression parses an HAProxy style rule, in the form
+//
+//	<action> [; <action> ...] if <condition> [<condition> ...]
+//
+// into ACL conditions and actions, or conditions alone, in the form
+//
+//	<condition> [<condition> ...]
+//
+// for ACLs whose actions are given separately. Conditions are combined with AND, and may be
+// negated with !. The -i flag is accepted and ignored, as matching behaviour is determined by the
+// ACL template.
+// The supported actions are:
+//
+//	redirect location <location> [code <status>]
+//	use_backend <target_group_id>
+//	[http-request] set-header <header> <value>
+//
+// The supported conditions are:
+//
+//	hdr(<header>) <value>
+//	path_beg <path>
+//	src <ip> [<ip> ...]
+//
+// Values which would otherwise be read as a keyword may be quoted
+func parseACLExpression(expression string) ([]loadbalancer.ACLCondition, []loadbalancer.ACLAction, error) {
+	tokens, err := tokenizeACLExpression(expression)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil, &aclExpressionError{Column: 1, Msg: "expression is empty"}
+	}
+
+	ifIndex := -1
+	for i, token := range tokens {
+		if token.quoted {
+			continue
+		}
+
+		switch token.value {
+		case "if":
+			if ifIndex < 0 {
+				ifIndex = i
+			}
+		case "unless":
+			return nil, nil, &aclExpressionError{Column: token.column, Token: token.value, Msg: "unless is not supported, use if with negated conditions"}
+		case "||", "or":
+			return nil, nil, &aclExpressionError{Column: token.column, Token: token.value, Msg: "OR is not supported, conditions are combined with AND"}
+		}
+	}
+
+	if ifIndex < 0 {
+		if !isACLExpressionAction(tokens[0]) {
+			conditions, err := parseACLExpressionConditions(tokens)
+			return conditions, nil, err
+		}
+
+		end := tokens[len(tokens)-1]
+		return nil, nil, &aclExpressionError{Column: end.column + len(end.value), Msg: "expected if followed by conditions"}
+	}
+
+	if ifIndex == 0 {
+		return nil, nil, &aclExpressionError{Column: tokens[0].column, Token: tokens[0].value, Msg: "expected action before if"}
+	}
+
+	actions, err := parseACLExpressionActions(tokens[:ifIndex])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if ifIndex == len(tokens)-1 {
+		return nil, nil, &aclExpressionError{Column: tokens[ifIndex].column, Token: tokens[ifIndex].value, Msg: "expected condition after if"}
+	}
+
+	conditions, err := parseACLExpressionConditions(tokens[ifIndex+1:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conditions, actions, nil
+}
+
+func parseACLExpressionActions(tokens []aclExpressionToken) ([]loadbalancer.ACLAction, error) {
+	var actions []loadbalancer.ACLAction
+
+	start := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) && (tokens[i].quoted || tokens[i].value != ";") {
+			continue
+		}
+
+		if i == start {
+			// Either side of a ; must be an action, so i is the index of an empty action's ;
+			// or follows a trailing ;
+			semicolon := tokens[min(i, len(tokens)-1)]
+			return nil, &aclExpressionError{Column: semicolon.column, Token: semicolon.value, Msg: "expected action"}
+		}
+
+		action, err := parseACLExpressionAction(tokens[start:i])
+		if err != nil {
+			return nil, err
+		}
+
+		actions = append(actions, action)
+		start = i + 1
+	}
+
+	return actions, nil
+}
+
+// isACLExpressionAction returns whether token begins an action, distinguishing expressions
+// missing their if from those with conditions alone
+func isACLExpressionAction(token aclExpressionToken) bool {
+	if token.quoted {
+		return false
+	}
+
+	switch token.value {
+	case "redirect", "use_backend", "set-header", "http-request":
+		return true
+	}
+
+	return false
+}
+
+func parseACLExpressionAction(tokens []aclExpressionToken) (loadbalancer.ACLAction, error) {
+	keyword := tokens[0]
+	if keyword.value == "http-request" && len(tokens) > 1 {
+		tokens = tokens[1:]
+		keyword = tokens[0]
+	}
+	args := tokens[1:]
+
+	switch keyword.value {
+	case "redirect":
+		if len(args) > 0 && args[0].value != "location" {
+			return loadbalancer.ACLAction{}, &aclExpressionError{Column: args[0].column, Token: args[0].value, Msg: "unsupported redirect type, expected location"}
+		}
+
+		if len(args) < 2 {
+			return loadbalancer.ACLAction{}, aclExpressionArgumentError(keyword, args, 2, "expected redirect location <location> [code <status>]")
+		}
+
+		action := loadbalancer.ACLAction{
+			Name: "redirect",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"location": {Name: "location", Value: args[1].value},
+			},
+		}
+
+		switch {
+		case len(args) == 2:
+		case len(args) == 4 && args[2].value == "code":
+			status, err := strconv.Atoi(args[3].value)
+			if err != nil {
+				return loadbalancer.ACLAction{}, &aclExpressionError{Column: args[3].column, Token: args[3].value, Msg: "redirect code must be a number"}
+			}
+			action.Arguments["status"] = loadbalancer.ACLArgument{Name: "status", Value: status}
+		default:
+			return loadbalancer.ACLAction{}, &aclExpressionError{Column: args[2].column, Token: args[2].value, Msg: "unexpected redirect argument, expected code <status>"}
+		}
+
+		return action, nil
+	case "use_backend":
+		if len(args) != 1 {
+			return loadbalancer.ACLAction{}, aclExpressionArgumentError(keyword, args, 1, "expected use_backend <target_group_id>")
+		}
+
+		targetGroupID, err := strconv.Atoi(args[0].value)
+		if err != nil {
+			return loadbalancer.ACLAction{}, &aclExpressionError{Column: args[0].column, Token: args[0].value, Msg: "use_backend requires a numeric target group ID"}
+		}
+
+		return loadbalancer.ACLAction{
+			Name: "use_target_group",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"target_group_id": {Name: "target_group_id", Value: targetGroupID},
+			},
+		}, nil
+	case "set-header":
+		if len(args) != 2 {
+			return loadbalancer.ACLAction{}, aclExpressionArgumentError(keyword, args, 2, "expected set-header <header> <value>")
+		}
+
+		return loadbalancer.ACLAction{
+			Name: "set_header",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"header": {Name: "header", Value: args[0].value},
+				"value":  {Name: "value", Value: args[1].value},
+			},
+		}, nil
+	}
+
+	return loadbalancer.ACLAction{}, &aclExpressionError{Column: keyword.column, Token: keyword.value, Msg: "unsupported action"}
+}
+
+func parseACLExpressionConditions(tokens []aclExpressionToken) ([]loadbalancer.ACLCondition, error) {
+	var conditions []loadbalancer.ACLCondition
+
+	for i := 0; i < len(tokens); {
+		fetch := tokens[i]
+		inverted := false
+		if !fetch.quoted && strings.HasPrefix(fetch.value, "!") {
+			inverted = true
+			fetch.value = strings.TrimPrefix(fetch.value, "!")
+			fetch.column++
+
+			if fetch.value == "" {
+				i++
+				if i >= len(tokens) {
+					return nil, &aclExpressionError{Column: fetch.column - 1, Token: "!", Msg: "expected condition after !"}
+				}
+				fetch = tokens[i]
+			}
+		}
+
+		// Patterns continue until the next fetch, which is always followed by at least one pattern
+		j := i + 1
+		for j < len(tokens) && !isACLExpressionFetch(tokens[j]) {
+			j++
+		}
+		patterns := tokens[i+1 : j]
+		i = j
+
+		condition, err := parseACLExpressionCondition(fetch, patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		condition.Inverted = inverted
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+func isACLExpressionFetch(token aclExpressionToken) bool {
+	if token.quoted {
+		return false
+	}
+
+	name := strings.TrimPrefix(token.value, "!")
+
+	return name == "" || name == "path_beg" || name == "src" || strings.HasPrefix(name, "hdr(")
+}
+
+func parseACLExpressionCondition(fetch aclExpressionToken, patterns []aclExpressionToken) (loadbalancer.ACLCondition, error) {
+	// Matching behaviour is determined by the ACL template, so -i is ignored and other flags,
+	// which would change what's matched, are rejected
+	var values []aclExpressionToken
+	for _, pattern := range patterns {
+		if pattern.quoted || !strings.HasPrefix(pattern.value, "-") {
+			values = append(values, pattern)
+			continue
+		}
+
+		if pattern.value == "-i" {
+			continue
+		}
+
+		return loadbalancer.ACLCondition{}, &aclExpressionError{Column: pattern.column, Token: pattern.value, Msg: "unsupported flag"}
+	}
+
+	switch {
+	case strings.HasPrefix(fetch.value, "hdr(") && strings.HasSuffix(fetch.value, ")"):
+		header := strings.TrimSuffix(strings.TrimPrefix(fetch.value, "hdr("), ")")
+		if header == "" {
+			return loadbalancer.ACLCondition{}, &aclExpressionError{Column: fetch.column, Token: fetch.value, Msg: "hdr requires a header name"}
+		}
+
+		if len(values) != 1 {
+			return loadbalancer.ACLCondition{}, aclExpressionArgumentError(fetch, values, 1, "hdr expects exactly one value")
+		}
+
+		return loadbalancer.ACLCondition{
+			Name: "header_matches",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"header": {Name: "header", Value: header},
+				"value":  {Name: "value", Value: values[0].value},
+			},
+		}, nil
+	case fetch.value == "path_beg":
+		if len(values) != 1 {
+			return loadbalancer.ACLCondition{}, aclExpressionArgumentError(fetch, values, 1, "path_beg expects exactly one path")
+		}
+
+		return loadbalancer.ACLCondition{
+			Name: "path_begins_with",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"path": {Name: "path", Value: values[0].value},
+			},
+		}, nil
+	case fetch.value == "src":
+		if len(values) < 1 {
+			return loadbalancer.ACLCondition{}, aclExpressionArgumentError(fetch, values, 1, "src expects at least one IP address or CIDR range")
+		}
+
+		var ips []string
+		for _, value := range values {
+			ips = append(ips, value.value)
+		}
+
+		return loadbalancer.ACLCondition{
+			Name: "source_ip",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"ip": {Name: "ip", Value: ips},
+			},
+		}, nil
+	}
+
+	return loadbalancer.ACLCondition{}, &aclExpressionError{Column: fetch.column, Token: fetch.value, Msg: "unsupported condition"}
+}
+
+// aclExpressionArgumentError returns an error for an invalid number of arguments, pointing at
+// the first unexpected argument where there are too many, otherwise the keyword
+func aclExpressionArgumentError(keyword aclExpressionToken, args []aclExpressionToken, expected int, msg string) error {
+	if len(args) > expected {
+		return &aclExpressionError{Column: args[expected].column, Token: args[expected].value, Msg: msg}
+	}
+
+	return &aclExpressionError{Column: keyword.column, Token: keyword.value, Msg: msg}
+}
+
+func validateACLExpression(v interface{}, k string) ([]string, []error) {
+	if _, _, err := parseACLExpression(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+
+	return nil, nil
+}
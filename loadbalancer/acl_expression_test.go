@@ -0,0 +1,192 @@
+package loadbalancer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+func TestParseACLExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		conditions []loadbalancer.ACLCondition
+		actions    []loadbalancer.ACLAction
+	}{
+		{
+			name:       "header with ignored case insensitive flag",
+			expression: "use_backend 12 if hdr(host) -i api.example.com",
+			conditions: []loadbalancer.ACLCondition{
+				{
+					Name: "header_matches",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"header": {Name: "header", Value: "host"},
+						"value":  {Name: "value", Value: "api.example.com"},
+					},
+				},
+			},
+			actions: []loadbalancer.ACLAction{
+				{
+					Name: "use_target_group",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"target_group_id": {Name: "target_group_id", Value: 12},
+					},
+				},
+			},
+		},
+		{
+			name:       "multiple conditions with negation",
+			expression: "redirect location https://developers.ukfast.io code 302 if path_beg /static !src 10.0.0.0/8 192.168.0.1",
+			conditions: []loadbalancer.ACLCondition{
+				{
+					Name: "path_begins_with",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"path": {Name: "path", Value: "/static"},
+					},
+				},
+				{
+					Name:     "source_ip",
+					Inverted: true,
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"ip": {Name: "ip", Value: []string{"10.0.0.0/8", "192.168.0.1"}},
+					},
+				},
+			},
+			actions: []loadbalancer.ACLAction{
+				{
+					Name: "redirect",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"location": {Name: "location", Value: "https://developers.ukfast.io"},
+						"status":   {Name: "status", Value: 302},
+					},
+				},
+			},
+		},
+		{
+			name:       "multiple actions with quoted value and detached negation",
+			expression: `http-request set-header X-Forwarded-Proto "https; secure"; use_backend 3 if ! hdr(x-internal) 'if'`,
+			conditions: []loadbalancer.ACLCondition{
+				{
+					Name:     "header_matches",
+					Inverted: true,
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"header": {Name: "header", Value: "x-internal"},
+						"value":  {Name: "value", Value: "if"},
+					},
+				},
+			},
+			actions: []loadbalancer.ACLAction{
+				{
+					Name: "set_header",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"header": {Name: "header", Value: "X-Forwarded-Proto"},
+						"value":  {Name: "value", Value: "https; secure"},
+					},
+				},
+				{
+					Name: "use_target_group",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"target_group_id": {Name: "target_group_id", Value: 3},
+					},
+				},
+			},
+		},
+		{
+			name:       "conditions only",
+			expression: "path_beg /static",
+			conditions: []loadbalancer.ACLCondition{
+				{
+					Name: "path_begins_with",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"path": {Name: "path", Value: "/static"},
+					},
+				},
+			},
+		},
+		{
+			name:       "conditions only with ignored case insensitive flag",
+			expression: "hdr(host) -i api.example.com !src 10.0.0.0/8",
+			conditions: []loadbalancer.ACLCondition{
+				{
+					Name: "header_matches",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"header": {Name: "header", Value: "host"},
+						"value":  {Name: "value", Value: "api.example.com"},
+					},
+				},
+				{
+					Name:     "source_ip",
+					Inverted: true,
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"ip": {Name: "ip", Value: []string{"10.0.0.0/8"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, actions, err := parseACLExpression(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(conditions, tt.conditions) {
+				t.Errorf("conditions: expected %#v, got %#v", tt.conditions, conditions)
+			}
+
+			if !reflect.DeepEqual(actions, tt.actions) {
+				t.Errorf("actions: expected %#v, got %#v", tt.actions, actions)
+			}
+		})
+	}
+}
+
+func TestParseACLExpression_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		column     int
+		token      string
+	}{
+		{name: "empty", expression: "  ", column: 1},
+		{name: "missing if", expression: "use_backend 1", column: 14},
+		{name: "missing action", expression: "if path_beg /", column: 1, token: "if"},
+		{name: "unsupported condition without action", expression: "path_end .php", column: 1, token: "path_end"},
+		{name: "missing condition", expression: "use_backend 1 if", column: 15, token: "if"},
+		{name: "unsupported action", expression: "deny if path_beg /", column: 1, token: "deny"},
+		{name: "unsupported condition", expression: "use_backend 1 if path_end .php", column: 18, token: "path_end"},
+		{name: "unsupported flag", expression: "use_backend 1 if hdr(host) -m beg api", column: 28, token: "-m"},
+		{name: "unless", expression: "use_backend 1 unless path_beg /", column: 15, token: "unless"},
+		{name: "or", expression: "use_backend 1 if path_beg /a || path_beg /b", column: 30, token: "||"},
+		{name: "non-numeric target group", expression: "use_backend web if path_beg /", column: 13, token: "web"},
+		{name: "non-numeric redirect code", expression: "redirect location / code found if path_beg /", column: 26, token: "found"},
+		{name: "unsupported redirect type", expression: "redirect prefix / if path_beg /", column: 10, token: "prefix"},
+		{name: "too many header values", expression: "use_backend 1 if hdr(host) a b", column: 30, token: "b"},
+		{name: "header name missing", expression: "use_backend 1 if hdr() a", column: 18, token: "hdr()"},
+		{name: "empty action", expression: "use_backend 1 ; ; use_backend 2 if path_beg /", column: 17, token: ";"},
+		{name: "trailing semicolon", expression: "use_backend 1; if path_beg /", column: 14, token: ";"},
+		{name: "unterminated quote", expression: `use_backend 1 if hdr(host) "api`, column: 28, token: `"api`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseACLExpression(tt.expression)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+
+			var expressionErr *aclExpressionError
+			if !errors.As(err, &expressionErr) {
+				t.Fatalf("expected *aclExpressionError, got %T", err)
+			}
+
+			if expressionErr.Column != tt.column || expressionErr.Token != tt.token {
+				t.Errorf("expected error at column %d with token %q, got %s", tt.column, tt.token, err)
+			}
+		})
+	}
+}
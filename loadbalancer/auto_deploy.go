@@ -0,0 +1,315 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// autoDeployService is returned as the provider meta when auto_deploy is configured. It embeds
+// the providerService so that resources can continue to use it as such
+type autoDeployService struct {
+	*providerService
+
+	settleDelay   time.Duration
+	timeout       time.Duration
+	warnOnFailure bool
+
+	// deploy deploys a cluster, waiting for the deployment to complete
+	deploy func(ctx context.Context, clusterID int) error
+
+	mu       sync.Mutex
+	clusters map[int]*clusterChanges
+}
+
+// clusterChanges tracks the in-flight changes and pending deployments for a single cluster
+type clusterChanges struct {
+	inFlight   int
+	generation int
+
+	// begun is closed when a change to the cluster begins, waking any change waiting to deploy it
+	begun chan struct{}
+
+	// pending is the deployment covering changes made since the last deployment started, and is
+	// nil when there are no such changes. running is the deployment in progress, if any
+	pending *clusterDeployment
+	running *clusterDeployment
+}
+
+// clusterDeployment is a deployment of a cluster shared by each change it covers
+type clusterDeployment struct {
+	started bool
+
+	// done is closed once the deployment completes, with its result in err
+	done chan struct{}
+	err  error
+}
+
+func newAutoDeployService(service *providerService, settleDelay time.Duration, timeout time.Duration, warnOnFailure bool) *autoDeployService {
+	s := &autoDeployService{
+		providerService: service,
+		settleDelay:     settleDelay,
+		timeout:         timeout,
+		warnOnFailure:   warnOnFailure,
+		clusters:        make(map[int]*clusterChanges),
+	}
+
+	s.deploy = func(ctx context.Context, clusterID int) error {
+		_, err := deployCluster(ctx, s.LoadBalancerService, clusterID, s.timeout)
+		return err
+	}
+
+	return s
+}
+
+func (s *autoDeployService) begin(clusterID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes, ok := s.clusters[clusterID]
+	if !ok {
+		changes = &clusterChanges{begun: make(chan struct{})}
+		s.clusters[clusterID] = changes
+	}
+
+	changes.inFlight++
+	changes.generation++
+
+	close(changes.begun)
+	changes.begun = make(chan struct{})
+}
+
+// end records the completion of a change to the cluster. If no further changes to the cluster
+// are started within the settle delay, the pending deployment of the cluster is made. Where a
+// further change starts, end returns immediately, leaving the deployment to that change.
+//
+// Changes which complete while the cluster is being deployed share a single deployment once it
+// has completed, rather than each deploying the cluster. A failed deployment is returned as an
+// error, or as a warning when warnOnFailure is set so the change itself isn't tainted. The
+// cluster remains pending deployment, so it's deployed again after the next change
+func (s *autoDeployService) end(ctx context.Context, clusterID int, changed bool) diag.Diagnostics {
+	s.mu.Lock()
+	changes := s.clusters[clusterID]
+	changes.inFlight--
+	if changed && changes.pending == nil {
+		changes.pending = &clusterDeployment{done: make(chan struct{})}
+	}
+	deployment := changes.pending
+	if changes.inFlight > 0 || deployment == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	generation := changes.generation
+	begun := changes.begun
+	s.mu.Unlock()
+
+	tflog.Debug(ctx, "waiting for further changes to cluster before deploying", map[string]any{
+		"cluster_id":   clusterID,
+		"settle_delay": s.settleDelay.String(),
+	})
+
+	select {
+	case <-ctx.Done():
+		return diag.FromErr(ctx.Err())
+	case <-begun:
+	case <-time.After(s.settleDelay):
+	}
+
+	s.mu.Lock()
+	if changes.inFlight > 0 || changes.generation != generation {
+		// A later change has started, which will take over responsibility for the deployment
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+
+	err := s.await(ctx, clusterID, changes, deployment)
+	if err == nil {
+		return nil
+	}
+
+	severity := diag.Error
+	detail := fmt.Sprintf("%s\n\nThe change has been staged, and the cluster will be deployed again after its next change. It can also be deployed manually.", err)
+	if s.warnOnFailure {
+		severity = diag.Warning
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: severity,
+			Summary:  fmt.Sprintf("Error auto deploying cluster with ID [%d]", clusterID),
+			Detail:   detail,
+		},
+	}
+}
+
+// await waits for deployment of the cluster to complete, starting it once any deployment
+// already in progress has completed, as the API deploys the whole of the staged configuration
+func (s *autoDeployService) await(ctx context.Context, clusterID int, changes *clusterChanges, deployment *clusterDeployment) error {
+	for {
+		s.mu.Lock()
+		if deployment.started {
+			s.mu.Unlock()
+			break
+		}
+
+		running := changes.running
+		if running == nil {
+			deployment.started = true
+			changes.pending = nil
+			changes.running = deployment
+			s.mu.Unlock()
+
+			err := s.deploy(ctx, clusterID)
+
+			s.mu.Lock()
+			deployment.err = err
+			changes.running = nil
+			if err != nil && changes.pending == nil {
+				changes.pending = &clusterDeployment{done: make(chan struct{})}
+			}
+			close(deployment.done)
+			s.mu.Unlock()
+
+			return err
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-running.done:
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-deployment.done:
+		return deployment.err
+	}
+}
+
+// resourceGetter is satisfied by both *schema.ResourceData and *schema.ResourceDiff, allowing
+// clusters to be resolved during plan as well as apply
+type resourceGetter interface {
+	Id() string
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// clusterIDFunc resolves the ID of the cluster a resource belongs to
+type clusterIDFunc func(d resourceGetter, service loadbalancerservice.LoadBalancerService) (int, error)
+
+// autoDeploy wraps a Create, Update or Delete function, recording the change against the
+// resource's cluster when the provider is configured with auto_deploy
+func autoDeploy(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, getClusterID clusterIDFunc) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		service, ok := meta.(*autoDeployService)
+		if !ok {
+			return f(ctx, d, meta)
+		}
+
+		clusterID, err := getClusterID(d, service.LoadBalancerService)
+		if err != nil {
+			// A parent which no longer exists has taken the resource with it, so there's no
+			// change to the cluster to deploy
+			var listenerNotFoundError *loadbalancerservice.ListenerNotFoundError
+			var targetGroupNotFoundError *loadbalancerservice.TargetGroupNotFoundError
+			if errors.As(err, &listenerNotFoundError) || errors.As(err, &targetGroupNotFoundError) {
+				tflog.Debug(ctx, "parent not found, skipping auto deployment", map[string]any{
+					"error": err.Error(),
+				})
+
+				return f(ctx, d, meta)
+			}
+
+			return diag.Errorf("Error resolving cluster for auto deployment: %s", err)
+		}
+
+		service.begin(clusterID)
+		diags := f(ctx, d, meta)
+
+		return append(diags, service.end(ctx, clusterID, !diags.HasError())...)
+	}
+}
+
+// autoDeployClusters wraps a Create, Update or Delete function changing several clusters,
+// recording the change against each of them when the provider is configured with auto_deploy
+func autoDeployClusters(f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, getClusterIDs func(d resourceGetter) []int) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		service, ok := meta.(*autoDeployService)
+		if !ok {
+			return f(ctx, d, meta)
+		}
+
+		clusterIDs := getClusterIDs(d)
+		for _, clusterID := range clusterIDs {
+			service.begin(clusterID)
+		}
+
+		diags := f(ctx, d, meta)
+		changed := !diags.HasError()
+
+		// Each cluster waits out the settle delay, so are ended concurrently
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		for _, clusterID := range clusterIDs {
+			wg.Add(1)
+			go func(clusterID int) {
+				defer wg.Done()
+
+				endDiags := service.end(ctx, clusterID, changed)
+
+				mu.Lock()
+				defer mu.Unlock()
+				diags = append(diags, endDiags...)
+			}(clusterID)
+		}
+		wg.Wait()
+
+		return diags
+	}
+}
+
+func clusterIDFromID(d resourceGetter, service loadbalancerservice.LoadBalancerService) (int, error) {
+	return strconv.Atoi(d.Id())
+}
+
+func clusterIDFromClusterID(d resourceGetter, service loadbalancerservice.LoadBalancerService) (int, error) {
+	return d.Get("cluster_id").(int), nil
+}
+
+func clusterIDFromListenerID(d resourceGetter, service loadbalancerservice.LoadBalancerService) (int, error) {
+	listener, err := service.GetListener(d.Get("listener_id").(int))
+	if err != nil {
+		return 0, err
+	}
+
+	return listener.ClusterID, nil
+}
+
+func clusterIDFromTargetGroupID(d resourceGetter, service loadbalancerservice.LoadBalancerService) (int, error) {
+	targetGroup, err := service.GetTargetGroup(d.Get("target_group_id").(int))
+	if err != nil {
+		return 0, err
+	}
+
+	return targetGroup.ClusterID, nil
+}
+
+func clusterIDFromListenerOrTargetGroupID(d resourceGetter, service loadbalancerservice.LoadBalancerService) (int, error) {
+	if _, ok := d.GetOk("listener_id"); ok {
+		return clusterIDFromListenerID(d, service)
+	}
+
+	return clusterIDFromTargetGroupID(d, service)
+}
@@ -0,0 +1,163 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// testAutoDeployService returns an autoDeployService recording the clusters it deploys, with
+// each deployment blocking until release is closed where given
+func testAutoDeployService(release chan struct{}, err error) (*autoDeployService, *[]int) {
+	s := newAutoDeployService(nil, 10*time.Millisecond, time.Minute, false)
+
+	var mu sync.Mutex
+	var deployed []int
+	s.deploy = func(ctx context.Context, clusterID int) error {
+		mu.Lock()
+		deployed = append(deployed, clusterID)
+		mu.Unlock()
+
+		if release != nil {
+			<-release
+		}
+		return err
+	}
+
+	return s, &deployed
+}
+
+func TestAutoDeployConcurrentChanges(t *testing.T) {
+	s, deployed := testAutoDeployService(nil, nil)
+
+	for i := 0; i < 5; i++ {
+		s.begin(1)
+	}
+	s.begin(2)
+
+	var wg sync.WaitGroup
+	for _, clusterID := range []int{1, 1, 1, 1, 1, 2} {
+		wg.Add(1)
+		go func(clusterID int) {
+			defer wg.Done()
+
+			if diags := s.end(context.Background(), clusterID, true); diags.HasError() {
+				t.Errorf("unexpected diagnostics %v", diags)
+			}
+		}(clusterID)
+	}
+	wg.Wait()
+
+	if len(*deployed) != 2 {
+		t.Errorf("expected each cluster to be deployed once, got %v", *deployed)
+	}
+}
+
+func TestAutoDeployHandsOverToLaterChange(t *testing.T) {
+	s, deployed := testAutoDeployService(nil, nil)
+	s.settleDelay = time.Minute
+
+	s.begin(1)
+
+	done := make(chan diag.Diagnostics)
+	go func() {
+		done <- s.end(context.Background(), 1, true)
+	}()
+
+	// A change beginning within the settle delay takes over the deployment
+	time.Sleep(time.Millisecond)
+	s.begin(1)
+
+	if diags := <-done; diags != nil || len(*deployed) != 0 {
+		t.Fatalf("expected first change to return without deploying, got %v and %v", diags, *deployed)
+	}
+
+	s.settleDelay = time.Millisecond
+	if diags := s.end(context.Background(), 1, false); diags != nil {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	if len(*deployed) != 1 {
+		t.Errorf("expected the later change to deploy the cluster once, got %v", *deployed)
+	}
+}
+
+func TestAutoDeployCoalescesChangesDuringDeployment(t *testing.T) {
+	release := make(chan struct{})
+	s, deployed := testAutoDeployService(release, nil)
+
+	s.begin(1)
+	first := make(chan diag.Diagnostics)
+	go func() {
+		first <- s.end(context.Background(), 1, true)
+	}()
+
+	for {
+		s.mu.Lock()
+		running := s.clusters[1].running != nil
+		s.mu.Unlock()
+		if running {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Changes completing while the cluster is deployed share the next deployment
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		s.begin(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if diags := s.end(context.Background(), 1, true); diags != nil {
+				t.Errorf("unexpected diagnostics %v", diags)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	wg.Wait()
+	if diags := <-first; diags != nil {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+
+	if len(*deployed) != 2 {
+		t.Errorf("expected changes during deployment to be deployed once more, got %v", *deployed)
+	}
+}
+
+func TestAutoDeployFailure(t *testing.T) {
+	for name, tc := range map[string]struct {
+		warnOnFailure bool
+		expected      diag.Severity
+	}{
+		"error":   {false, diag.Error},
+		"warning": {true, diag.Warning},
+	} {
+		t.Run(name, func(t *testing.T) {
+			s, deployed := testAutoDeployService(nil, errors.New("deployment with ID [1] failed"))
+			s.warnOnFailure = tc.warnOnFailure
+
+			s.begin(1)
+			diags := s.end(context.Background(), 1, true)
+			if len(diags) != 1 || diags[0].Severity != tc.expected {
+				t.Fatalf("expected failed deployment to be reported with severity %v, got %v", tc.expected, diags)
+			}
+
+			// The cluster remains pending, so is deployed again after its next change
+			s.begin(1)
+			s.end(context.Background(), 1, false)
+
+			if len(*deployed) != 2 {
+				t.Errorf("expected cluster to be deployed again, got %v", *deployed)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+// clusterConfigService provides the calls required to retrieve the staged configuration of a
+// cluster
+type clusterConfigService interface {
+	loadbalancerservice.LoadBalancerService
+	aclManager
+}
+
+// clusterConfig is the staged configuration of a cluster, as retrieved from the API
+type clusterConfig struct {
+	Cluster      loadbalancerservice.Cluster
+	VIPs         []loadbalancerservice.VIP
+	Listeners    []listenerConfig
+	TargetGroups []targetGroupConfig
+}
+
+type listenerConfig struct {
+	loadbalancerservice.Listener
+
+	Binds        []loadbalancerservice.Bind
+	Certificates []loadbalancerservice.Certificate
+	AccessIPs    []loadbalancerservice.AccessIP
+	ACLs         []aclWithPriority
+}
+
+type targetGroupConfig struct {
+	loadbalancerservice.TargetGroup
+
+	Targets []loadbalancerservice.Target
+	ACLs    []aclWithPriority
+}
+
+// getClusterConfig retrieves the staged configuration of a cluster
+func getClusterConfig(service clusterConfigService, clusterID int) (clusterConfig, error) {
+	cluster, err := service.GetCluster(clusterID)
+	if err != nil {
+		return clusterConfig{}, fmt.Errorf("error retrieving cluster: %w", err)
+	}
+
+	clusterParams := connection.APIRequestParameters{}
+	clusterParams.WithFilter(*connection.NewAPIRequestFiltering("cluster_id", connection.EQOperator, []string{strconv.Itoa(clusterID)}))
+
+	vips, err := service.GetVIPs(clusterParams)
+	if err != nil {
+		return clusterConfig{}, fmt.Errorf("error retrieving VIPs: %w", err)
+	}
+
+	listeners, err := service.GetListeners(clusterParams)
+	if err != nil {
+		return clusterConfig{}, fmt.Errorf("error retrieving listeners: %w", err)
+	}
+
+	targetGroups, err := service.GetTargetGroups(clusterParams)
+	if err != nil {
+		return clusterConfig{}, fmt.Errorf("error retrieving target groups: %w", err)
+	}
+
+	config := clusterConfig{
+		Cluster: cluster,
+		VIPs:    vips,
+	}
+
+	for _, listener := range listeners {
+		l := listenerConfig{Listener: listener}
+
+		l.Binds, err = service.GetListenerBinds(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return clusterConfig{}, fmt.Errorf("error retrieving binds for listener with ID [%d]: %w", listener.ID, err)
+		}
+
+		l.Certificates, err = service.GetListenerCertificates(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return clusterConfig{}, fmt.Errorf("error retrieving certificates for listener with ID [%d]: %w", listener.ID, err)
+		}
+
+		l.AccessIPs, err = service.GetListenerAccessIPs(listener.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return clusterConfig{}, fmt.Errorf("error retrieving access IPs for listener with ID [%d]: %w", listener.ID, err)
+		}
+
+		l.ACLs, err = getACLsWithPriority(service, "listener_id", listener.ID)
+		if err != nil {
+			return clusterConfig{}, fmt.Errorf("error retrieving ACLs for listener with ID [%d]: %w", listener.ID, err)
+		}
+
+		config.Listeners = append(config.Listeners, l)
+	}
+
+	for _, targetGroup := range targetGroups {
+		tg := targetGroupConfig{TargetGroup: targetGroup}
+
+		tg.Targets, err = service.GetTargetGroupTargets(targetGroup.ID, connection.APIRequestParameters{})
+		if err != nil {
+			return clusterConfig{}, fmt.Errorf("error retrieving targets for target group with ID [%d]: %w", targetGroup.ID, err)
+		}
+
+		tg.ACLs, err = getACLsWithPriority(service, "target_group_id", targetGroup.ID)
+		if err != nil {
+			return clusterConfig{}, fmt.Errorf("error retrieving ACLs for target group with ID [%d]: %w", targetGroup.ID, err)
+		}
+
+		config.TargetGroups = append(config.TargetGroups, tg)
+	}
+
+	return config, nil
+}
+
+// getACLsWithPriority retrieves the ACLs of the listener or target group identified by key
+func getACLsWithPriority(service aclManager, key string, id int) ([]aclWithPriority, error) {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering(key, connection.EQOperator, []string{strconv.Itoa(id)}))
+
+	return service.GetACLsWithPriority(params)
+}
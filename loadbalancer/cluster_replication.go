@@ -0,0 +1,654 @@
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// replicationChange is a change required for a replica cluster to match its source cluster.
+// The description is reported as drift until the change is applied
+type replicationChange struct {
+	description string
+	apply       func() error
+}
+
+// replicationPlanner determines the changes required for a replica cluster to match its source.
+// Target groups and listeners are matched by name, targets by IP and port, binds by VIP and
+// port, and access IPs by IP
+type replicationPlanner struct {
+	ctx       context.Context
+	service   clusterConfigService
+	source    clusterSnapshot
+	replica   clusterSnapshot
+	replicaID int
+
+	// vips maps source VIP IDs to replica VIP IDs
+	vips map[int]int
+
+	// targetGroupIDs and listenerIDs map names to the IDs of replica objects, and are updated
+	// as changes creating them are applied
+	targetGroupIDs map[string]int
+	listenerIDs    map[string]int
+
+	changes []replicationChange
+}
+
+// planReplication returns the changes required for the replica cluster to match source.
+// vipMapping maps source VIP IDs to the VIP IDs of any number of replicas, with mappings to VIPs
+// of other clusters ignored
+func planReplication(ctx context.Context, service clusterConfigService, source clusterSnapshot, replicaID int, vipMapping map[int][]int) ([]replicationChange, error) {
+	config, err := getClusterConfig(service, replicaID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &replicationPlanner{
+		ctx:            ctx,
+		service:        service,
+		source:         source,
+		replica:        newClusterSnapshot(config),
+		replicaID:      replicaID,
+		targetGroupIDs: make(map[string]int),
+		listenerIDs:    make(map[string]int),
+	}
+
+	if err := p.mapVIPs(vipMapping); err != nil {
+		return nil, err
+	}
+
+	if err := p.plan(); err != nil {
+		return nil, err
+	}
+
+	return p.changes, nil
+}
+
+// reconcileReplica applies the changes required for the replica cluster to match source
+func reconcileReplica(ctx context.Context, service clusterConfigService, source clusterSnapshot, replicaID int, vipMapping map[int][]int) error {
+	changes, err := planReplication(ctx, service, source, replicaID, vipMapping)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		tflog.Info(ctx, "replicating change", map[string]any{
+			"cluster_id": replicaID,
+			"change":     change.description,
+		})
+
+		if err := change.apply(); err != nil {
+			return fmt.Errorf("%s: %w", change.description, err)
+		}
+	}
+
+	return nil
+}
+
+// unmappedVIPError is returned where a source VIP bound by a listener isn't mapped to a VIP of
+// the replica
+type unmappedVIPError struct {
+	vipID     int
+	listener  string
+	replicaID int
+}
+
+func (e *unmappedVIPError) Error() string {
+	return fmt.Sprintf("VIP with ID [%d] bound by listener [%s] isn't mapped to a VIP of cluster with ID [%d], a vip_mapping is required", e.vipID, e.listener, e.replicaID)
+}
+
+// clearedFieldsError is returned where values removed from a source target group or listener
+// would have to be cleared on the replica, which patching can't do
+type clearedFieldsError struct {
+	object string
+	id     int
+	fields []string
+}
+
+func (e *clearedFieldsError) Error() string {
+	return fmt.Sprintf("%s with ID [%d] of the replica can't be updated to clear %v, which the API doesn't support. "+
+		"Remove it from the replica for it to be recreated, or set these fields on the source", e.object, e.id, e.fields)
+}
+
+// mapVIPs maps each source VIP bound by a listener to a VIP of the replica. VIPs aren't matched
+// implicitly, as binding to the wrong VIP of a replica would expose a listener unexpectedly
+func (p *replicationPlanner) mapVIPs(vipMapping map[int][]int) error {
+	replicaVIPs := make(map[int]bool)
+	for _, vip := range p.replica.VIPs {
+		replicaVIPs[vip.ID] = true
+	}
+
+	p.vips = make(map[int]int)
+	for source, replicas := range vipMapping {
+		for _, replica := range replicas {
+			if replicaVIPs[replica] {
+				p.vips[source] = replica
+			}
+		}
+	}
+
+	for _, listener := range p.source.Listeners {
+		for _, bind := range listener.Binds {
+			if _, ok := p.vips[bind.VIPID]; !ok {
+				return &unmappedVIPError{vipID: bind.VIPID, listener: listener.Name, replicaID: p.replicaID}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p *replicationPlanner) change(description string, apply func() error) {
+	p.changes = append(p.changes, replicationChange{description: description, apply: apply})
+}
+
+func (p *replicationPlanner) plan() error {
+	sourceTargetGroups := make(map[string]bool)
+	for _, targetGroup := range p.source.TargetGroups {
+		if sourceTargetGroups[targetGroup.Name] {
+			return fmt.Errorf("target group names must be unique to be replicated, [%s] is used more than once", targetGroup.Name)
+		}
+		sourceTargetGroups[targetGroup.Name] = true
+	}
+
+	sourceListeners := make(map[string]bool)
+	for _, listener := range p.source.Listeners {
+		if sourceListeners[listener.Name] {
+			return fmt.Errorf("listener names must be unique to be replicated, [%s] is used more than once", listener.Name)
+		}
+		sourceListeners[listener.Name] = true
+	}
+
+	replicaTargetGroups := make(map[string]targetGroupSnapshot)
+	for _, targetGroup := range p.replica.TargetGroups {
+		if _, ok := replicaTargetGroups[targetGroup.Name]; !ok {
+			replicaTargetGroups[targetGroup.Name] = targetGroup
+			p.targetGroupIDs[targetGroup.Name] = targetGroup.ID
+		}
+	}
+
+	replicaListeners := make(map[string]listenerSnapshot)
+	for _, listener := range p.replica.Listeners {
+		if _, ok := replicaListeners[listener.Name]; !ok {
+			replicaListeners[listener.Name] = listener
+			p.listenerIDs[listener.Name] = listener.ID
+		}
+	}
+
+	for _, targetGroup := range p.source.TargetGroups {
+		existing, ok := replicaTargetGroups[targetGroup.Name]
+		if err := p.planTargetGroup(targetGroup, existing, ok); err != nil {
+			return err
+		}
+	}
+
+	for _, listener := range p.source.Listeners {
+		existing, ok := replicaListeners[listener.Name]
+		if err := p.planListener(listener, existing, ok); err != nil {
+			return err
+		}
+	}
+
+	// ACLs are planned once all target groups exist, as they may reference any target group
+	for _, targetGroup := range p.source.TargetGroups {
+		existing, ok := replicaTargetGroups[targetGroup.Name]
+		p.planACLs(fmt.Sprintf("target group [%s]", targetGroup.Name), "target_group_id", p.targetGroupIDs, targetGroup.Name, targetGroup.ACLs, existing.ACLs, ok)
+	}
+
+	for _, listener := range p.source.Listeners {
+		existing, ok := replicaListeners[listener.Name]
+		p.planACLs(fmt.Sprintf("listener [%s]", listener.Name), "listener_id", p.listenerIDs, listener.Name, listener.ACLs, existing.ACLs, ok)
+	}
+
+	// Listeners are removed ahead of target groups, which may be their default target group
+	for _, listener := range p.replica.Listeners {
+		if sourceListeners[listener.Name] && p.listenerIDs[listener.Name] == listener.ID {
+			continue
+		}
+
+		id := listener.ID
+		p.change(fmt.Sprintf("listener [%s] with ID [%d] isn't in source", listener.Name, id), func() error {
+			return p.service.DeleteListener(id)
+		})
+	}
+
+	for _, targetGroup := range p.replica.TargetGroups {
+		if sourceTargetGroups[targetGroup.Name] && p.targetGroupIDs[targetGroup.Name] == targetGroup.ID {
+			continue
+		}
+
+		id := targetGroup.ID
+		p.change(fmt.Sprintf("target group [%s] with ID [%d] isn't in source", targetGroup.Name, id), func() error {
+			return p.service.DeleteTargetGroup(id)
+		})
+	}
+
+	return nil
+}
+
+func (p *replicationPlanner) planTargetGroup(targetGroup targetGroupSnapshot, existing targetGroupSnapshot, exists bool) error {
+	name := targetGroup.Name
+	desired := targetGroup.CreateTargetGroupRequest
+	desired.ClusterID = p.replicaID
+
+	if !exists {
+		p.change(fmt.Sprintf("target group [%s] is missing", name), func() error {
+			id, err := p.service.CreateTargetGroup(desired)
+			p.targetGroupIDs[name] = id
+			return err
+		})
+	} else if current := normaliseTargetGroupRequest(existing.CreateTargetGroupRequest); !reflect.DeepEqual(current, normaliseTargetGroupRequest(desired)) {
+		patch, err := patchFromCreate[loadbalancerservice.PatchTargetGroupRequest](desired)
+		if err != nil {
+			return err
+		}
+
+		// Target groups aren't recreated, as their listeners and ACLs reference them by ID
+		cleared, err := clearedFields(current, patch)
+		if err != nil {
+			return err
+		}
+
+		if len(cleared) > 0 {
+			return &clearedFieldsError{object: fmt.Sprintf("target group [%s]", name), id: existing.ID, fields: cleared}
+		}
+
+		id := existing.ID
+		p.change(fmt.Sprintf("target group [%s] differs", name), func() error {
+			return p.service.PatchTargetGroup(id, patch)
+		})
+	}
+
+	replicaTargets := make(map[string]targetSnapshot)
+	for _, target := range existing.Targets {
+		replicaTargets[targetKey(target)] = target
+	}
+
+	sourceTargets := make(map[string]bool)
+	for _, target := range targetGroup.Targets {
+		key := targetKey(target)
+		sourceTargets[key] = true
+		desired := target.CreateTargetRequest
+
+		current, ok := replicaTargets[key]
+		switch {
+		case !ok:
+			p.change(fmt.Sprintf("target [%s] of target group [%s] is missing", key, name), func() error {
+				_, err := p.service.CreateTargetGroupTarget(p.targetGroupIDs[name], desired)
+				return err
+			})
+		case !reflect.DeepEqual(current.CreateTargetRequest, desired):
+			patch, err := patchFromCreate[loadbalancerservice.PatchTargetRequest](desired)
+			if err != nil {
+				return err
+			}
+
+			id := current.ID
+
+			// Targets aren't referenced by other objects, so are recreated where a patch can't
+			// clear their fields
+			cleared, err := clearedFields(current.CreateTargetRequest, patch)
+			if err != nil {
+				return err
+			}
+
+			if len(cleared) > 0 {
+				p.change(fmt.Sprintf("target [%s] of target group [%s] differs, and is recreated to clear %v", key, name, cleared), func() error {
+					if err := p.service.DeleteTargetGroupTarget(p.targetGroupIDs[name], id); err != nil {
+						return err
+					}
+
+					_, err := p.service.CreateTargetGroupTarget(p.targetGroupIDs[name], desired)
+					return err
+				})
+				continue
+			}
+
+			p.change(fmt.Sprintf("target [%s] of target group [%s] differs", key, name), func() error {
+				return p.service.PatchTargetGroupTarget(p.targetGroupIDs[name], id, patch)
+			})
+		}
+	}
+
+	for _, target := range existing.Targets {
+		key := targetKey(target)
+		if sourceTargets[key] && replicaTargets[key].ID == target.ID {
+			continue
+		}
+
+		id := target.ID
+		p.change(fmt.Sprintf("target [%s] of target group [%s] isn't in source", key, name), func() error {
+			return p.service.DeleteTargetGroupTarget(p.targetGroupIDs[name], id)
+		})
+	}
+
+	return nil
+}
+
+func (p *replicationPlanner) planListener(listener listenerSnapshot, existing listenerSnapshot, exists bool) error {
+	name := listener.Name
+
+	defaultTargetGroup := ""
+	if listener.DefaultTargetGroupID != 0 {
+		var ok bool
+		defaultTargetGroup, ok = p.sourceTargetGroupName(listener.DefaultTargetGroupID)
+		if !ok {
+			return fmt.Errorf("default target group with ID [%d] of listener [%s] isn't within the source cluster", listener.DefaultTargetGroupID, name)
+		}
+	}
+
+	// The default target group is resolved when applied, as it may be created by an earlier change
+	desired := func() loadbalancerservice.CreateListenerRequest {
+		req := listener.CreateListenerRequest
+		req.ClusterID = p.replicaID
+		req.DefaultTargetGroupID = p.targetGroupIDs[defaultTargetGroup]
+		return req
+	}
+
+	if !exists {
+		p.change(fmt.Sprintf("listener [%s] is missing", name), func() error {
+			id, err := p.service.CreateListener(desired())
+			p.listenerIDs[name] = id
+			return err
+		})
+	} else {
+		current := normaliseListenerRequest(existing.CreateListenerRequest)
+		expected := normaliseListenerRequest(listener.CreateListenerRequest)
+		currentDefault, _ := p.replicaTargetGroupName(existing.DefaultTargetGroupID)
+
+		if !reflect.DeepEqual(current, expected) || currentDefault != defaultTargetGroup {
+			patch, err := patchFromCreate[loadbalancerservice.PatchListenerRequest](expected)
+			if err != nil {
+				return err
+			}
+
+			// Listeners aren't recreated, as their certificates can't be read back to recreate them
+			cleared, err := clearedFields(current, patch)
+			if err != nil {
+				return err
+			}
+
+			if len(cleared) > 0 {
+				return &clearedFieldsError{object: fmt.Sprintf("listener [%s]", name), id: existing.ID, fields: cleared}
+			}
+
+			id := existing.ID
+			p.change(fmt.Sprintf("listener [%s] differs", name), func() error {
+				patch, err := patchFromCreate[loadbalancerservice.PatchListenerRequest](desired())
+				if err != nil {
+					return err
+				}
+
+				return p.service.PatchListener(id, patch)
+			})
+		}
+	}
+
+	existingBinds := make(map[string]int)
+	for _, bind := range existing.Binds {
+		existingBinds[fmt.Sprintf("%d:%d", bind.VIPID, bind.Port)] = bind.ID
+	}
+
+	desiredBinds := make(map[string]bool)
+	for _, bind := range listener.Binds {
+		vipID, ok := p.vips[bind.VIPID]
+		if !ok {
+			return fmt.Errorf("VIP with ID [%d] of listener [%s] isn't within the source cluster", bind.VIPID, name)
+		}
+
+		key := fmt.Sprintf("%d:%d", vipID, bind.Port)
+		desiredBinds[key] = true
+
+		if _, ok := existingBinds[key]; !ok {
+			req := loadbalancerservice.CreateBindRequest{VIPID: vipID, Port: bind.Port}
+			p.change(fmt.Sprintf("bind [%s] of listener [%s] is missing", key, name), func() error {
+				_, err := p.service.CreateListenerBind(p.listenerIDs[name], req)
+				return err
+			})
+		}
+	}
+
+	for _, bind := range existing.Binds {
+		key := fmt.Sprintf("%d:%d", bind.VIPID, bind.Port)
+		if desiredBinds[key] && existingBinds[key] == bind.ID {
+			continue
+		}
+
+		id := bind.ID
+		p.change(fmt.Sprintf("bind [%s] of listener [%s] isn't in source", key, name), func() error {
+			return p.service.DeleteListenerBind(p.listenerIDs[name], id)
+		})
+	}
+
+	existingAccessIPs := make(map[string]int)
+	for _, accessIP := range existing.AccessIPs {
+		existingAccessIPs[accessIP.IP] = accessIP.ID
+	}
+
+	desiredAccessIPs := make(map[string]bool)
+	for _, accessIP := range listener.AccessIPs {
+		ip := accessIP.IP
+		desiredAccessIPs[ip] = true
+
+		if _, ok := existingAccessIPs[ip]; !ok {
+			p.change(fmt.Sprintf("access IP [%s] of listener [%s] is missing", ip, name), func() error {
+				_, err := p.service.CreateListenerAccessIP(p.listenerIDs[name], loadbalancerservice.CreateAccessIPRequest{
+					IP: connection.IPAddress(ip),
+				})
+				return err
+			})
+		}
+	}
+
+	for _, accessIP := range existing.AccessIPs {
+		if desiredAccessIPs[accessIP.IP] && existingAccessIPs[accessIP.IP] == accessIP.ID {
+			continue
+		}
+
+		id := accessIP.ID
+		p.change(fmt.Sprintf("access IP [%s] of listener [%s] isn't in source", accessIP.IP, name), func() error {
+			return p.service.DeleteAccessIP(id)
+		})
+	}
+
+	return nil
+}
+
+// planACLs plans a change converging the ACLs of a replica listener or target group where they
+// differ from the source. The ACLs are converged as a whole, as ACLs can only be matched by
+// name and position
+func (p *replicationPlanner) planACLs(parent string, key string, parentIDs map[string]int, name string, source []aclSnapshot, existing []aclSnapshot, exists bool) {
+	desired := func() ([]aclWithPriority, error) {
+		targetGroups := make(map[int]int)
+		for _, targetGroup := range p.source.TargetGroups {
+			if id, ok := p.targetGroupIDs[targetGroup.Name]; ok && id != 0 {
+				targetGroups[targetGroup.ID] = id
+			}
+		}
+
+		var acls []aclWithPriority
+		for _, acl := range source {
+			actions, err := remapACLActions(acl.Actions, targetGroups)
+			if err != nil {
+				return nil, err
+			}
+
+			desired := aclWithPriority{
+				ACL: loadbalancerservice.ACL{
+					Name:       acl.Name,
+					Conditions: acl.Conditions,
+					Actions:    actions,
+				},
+				Priority: acl.Priority,
+			}
+
+			if key == "listener_id" {
+				desired.ListenerID = parentIDs[name]
+			} else {
+				desired.TargetGroupID = parentIDs[name]
+			}
+
+			acls = append(acls, desired)
+		}
+
+		return acls, nil
+	}
+
+	if exists && !p.aclsDiffer(desired, existing) {
+		return
+	}
+
+	if !exists && len(source) == 0 {
+		return
+	}
+
+	p.change(fmt.Sprintf("ACLs of %s differ", parent), func() error {
+		acls, err := desired()
+		if err != nil {
+			return err
+		}
+
+		current, err := getACLsWithPriority(p.service, key, parentIDs[name])
+		if err != nil {
+			return err
+		}
+
+		return convergeACLs(p.ctx, p.service, acls, current)
+	})
+}
+
+// aclsDiffer returns whether existing differs from the desired ACLs, which differ where they
+// reference target groups missing from the replica
+func (p *replicationPlanner) aclsDiffer(desired func() ([]aclWithPriority, error), existing []aclSnapshot) bool {
+	acls, err := desired()
+	if err != nil || len(acls) != len(existing) {
+		return true
+	}
+
+	var current []aclWithPriority
+	for _, acl := range existing {
+		current = append(current, aclWithPriority{
+			ACL: loadbalancerservice.ACL{
+				ID:         acl.ID,
+				Name:       acl.Name,
+				Conditions: acl.Conditions,
+				Actions:    acl.Actions,
+			},
+			Priority: acl.Priority,
+		})
+	}
+
+	matches, unmatched := matchACLs(acls, current)
+	if len(unmatched) > 0 {
+		return true
+	}
+
+	for i, acl := range acls {
+		if matches[i] == nil || !aclsEqual(acl, *matches[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *replicationPlanner) sourceTargetGroupName(id int) (string, bool) {
+	for _, targetGroup := range p.source.TargetGroups {
+		if targetGroup.ID == id {
+			return targetGroup.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func (p *replicationPlanner) replicaTargetGroupName(id int) (string, bool) {
+	for _, targetGroup := range p.replica.TargetGroups {
+		if targetGroup.ID == id {
+			return targetGroup.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func targetKey(target targetSnapshot) string {
+	return target.IP.String() + ":" + strconv.Itoa(target.Port)
+}
+
+func normaliseTargetGroupRequest(req loadbalancerservice.CreateTargetGroupRequest) loadbalancerservice.CreateTargetGroupRequest {
+	req.ClusterID = 0
+	return req
+}
+
+// normaliseListenerRequest removes the IDs of a listener request, which differ between clusters
+func normaliseListenerRequest(req loadbalancerservice.CreateListenerRequest) loadbalancerservice.CreateListenerRequest {
+	req.ClusterID = 0
+	req.DefaultTargetGroupID = 0
+	return req
+}
+
+// clearedFields returns the JSON names of fields set in current which aren't set by patch. Patch
+// requests omit empty values, so these fields can't be cleared by patching
+func clearedFields(current interface{}, patch interface{}) ([]string, error) {
+	currentFields, err := jsonFields(current)
+	if err != nil {
+		return nil, err
+	}
+
+	patchFields, err := jsonFields(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var cleared []string
+	for field, value := range currentFields {
+		if _, ok := patchFields[field]; ok {
+			continue
+		}
+
+		if value == nil || value == "" || value == float64(0) || value == false {
+			continue
+		}
+
+		cleared = append(cleared, field)
+	}
+	sort.Strings(cleared)
+
+	return cleared, nil
+}
+
+// jsonFields returns the fields of v as encoded to JSON
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	err = json.Unmarshal(b, &fields)
+
+	return fields, err
+}
+
+// patchFromCreate converts a create request into the equivalent patch request, as their fields
+// share JSON names. Boolean fields of patch requests are pointers, so are always set
+func patchFromCreate[T any](create interface{}) (T, error) {
+	var patch T
+
+	b, err := json.Marshal(create)
+	if err != nil {
+		return patch, err
+	}
+
+	err = json.Unmarshal(b, &patch)
+
+	return patch, err
+}
@@ -0,0 +1,138 @@
+package loadbalancer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestReconcileReplica(t *testing.T) {
+	source := testClusterConfig()
+	source.VIPs = append(source.VIPs, loadbalancer.VIP{ID: 9, ClusterID: 1})
+	snapshot := newClusterSnapshot(source)
+
+	service := newFakeService(t, testClusterConfig())
+	service.config.VIPs = source.VIPs
+
+	// Only VIP 2 of the replica is mapped, and source VIPs aren't matched implicitly
+	vipMapping := map[int][]int{2: {2}}
+	_, err := planReplication(context.Background(), service, snapshot, 2, vipMapping)
+	if err == nil || err.Error() != "VIP with ID [1] bound by listener [web] isn't mapped to a VIP of cluster with ID [2], a vip_mapping is required" {
+		t.Fatalf("expected unmapped VIP error, got %v", err)
+	}
+
+	vipMapping = map[int][]int{1: {1, 101}, 2: {2}, 9: {9}}
+	changes, err := planReplication(context.Background(), service, snapshot, 2, vipMapping)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected identical replica to be in sync, got %v", changes)
+	}
+
+	// The replica differs by a missing target, a target group setting and an extra target group
+	replica := &service.config.TargetGroups[1]
+	replica.Targets = replica.Targets[:1]
+	replica.Balance = loadbalancer.TargetGroupBalanceSource
+	service.config.TargetGroups = append(service.config.TargetGroups, targetGroupConfig{
+		TargetGroup: loadbalancer.TargetGroup{ID: 22, Name: "old"},
+	})
+
+	changes, err = planReplication(context.Background(), service, snapshot, 2, vipMapping)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var drift []string
+	for _, change := range changes {
+		drift = append(drift, change.description)
+	}
+
+	expectedDrift := []string{
+		"target group [web] differs",
+		"target [10.0.1.10:443] of target group [web] is missing",
+		"target group [old] with ID [22] isn't in source",
+	}
+	if !reflect.DeepEqual(drift, expectedDrift) {
+		t.Errorf("expected drift %v, got %v", expectedDrift, drift)
+	}
+
+	err = reconcileReplica(context.Background(), service, snapshot, 2, vipMapping)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedChanges := []string{
+		"patch target group 20 balance roundrobin",
+		"create target 1 10.0.1.10:443 in target group 20",
+		"delete target group 22",
+	}
+	if !reflect.DeepEqual(service.changes, expectedChanges) {
+		t.Errorf("expected changes %v, got %v", expectedChanges, service.changes)
+	}
+}
+
+func TestReconcileReplicaClearedFields(t *testing.T) {
+	vipMapping := map[int][]int{1: {1}, 2: {2}, 9: {9}}
+	source := testClusterConfig()
+	source.VIPs = append(source.VIPs, loadbalancer.VIP{ID: 9, ClusterID: 1})
+
+	// Clearing the session cookie value of a target can't be patched, so the target is recreated
+	sourceTarget := &source.TargetGroups[1].Targets[0]
+	sourceTarget.SessionCookieValue = ""
+
+	service := newFakeService(t, testClusterConfig())
+	service.config.VIPs = source.VIPs
+	service.nextID = 200
+
+	err := reconcileReplica(context.Background(), service, newClusterSnapshot(source), 2, vipMapping)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedChanges := []string{
+		"delete target 101 in target group 20",
+		"create target 201 10.0.1.11:443 in target group 20",
+	}
+	if !reflect.DeepEqual(service.changes, expectedChanges) {
+		t.Errorf("expected changes %v, got %v", expectedChanges, service.changes)
+	}
+
+	// The replica converges once the recreated target is read back
+	replicaTarget := &service.config.TargetGroups[1].Targets[0]
+	replicaTarget.ID = 201
+	replicaTarget.SessionCookieValue = ""
+
+	changes, err := planReplication(context.Background(), service, newClusterSnapshot(source), 2, vipMapping)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("expected replica to be in sync, got %v", changes)
+	}
+
+	// Target groups are referenced by ID, so clearing their fields is reported instead
+	source.TargetGroups[1].CustomOptions = ""
+	source.TargetGroups[1].TimeoutsConnect = 0
+
+	_, err = planReplication(context.Background(), service, newClusterSnapshot(source), 2, vipMapping)
+	if err == nil || !strings.Contains(err.Error(), "target group [web] with ID [20] of the replica can't be updated to clear [custom_options timeouts_connect]") {
+		t.Errorf("expected cleared fields error, got %v", err)
+	}
+}
+
+func TestResourceClusterReplicationDiffSourceReplica(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source_cluster_id":   1,
+		"replica_cluster_ids": []interface{}{1, 2},
+	})
+
+	_, err := resourceClusterReplication().Diff(context.Background(), nil, config, nil)
+	if err == nil || !strings.Contains(err.Error(), "source cluster with ID [1] can't be a replica of itself") {
+		t.Errorf("expected source cluster to be rejected as a replica, got %v", err)
+	}
+}
@@ -0,0 +1,623 @@
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// clusterSnapshotVersion is the version of the snapshot document format, which must be
+// incremented for changes which older versions of restore can't handle
+const clusterSnapshotVersion = 1
+
+// clusterSnapshot is a versioned document holding the staged configuration of a cluster. IDs
+// are those of the source cluster, and are remapped when the snapshot is restored
+type clusterSnapshot struct {
+	Version      int                   `json:"version"`
+	ClusterID    int                   `json:"cluster_id"`
+	ClusterName  string                `json:"cluster_name"`
+	VIPs         []vipSnapshot         `json:"vips"`
+	TargetGroups []targetGroupSnapshot `json:"target_groups"`
+	Listeners    []listenerSnapshot    `json:"listeners"`
+}
+
+type vipSnapshot struct {
+	ID           int    `json:"id"`
+	InternalCIDR string `json:"internal_cidr"`
+	ExternalCIDR string `json:"external_cidr"`
+}
+
+type targetGroupSnapshot struct {
+	ID int `json:"id"`
+	loadbalancerservice.CreateTargetGroupRequest
+
+	Targets []targetSnapshot `json:"targets"`
+	ACLs    []aclSnapshot    `json:"acls"`
+}
+
+type targetSnapshot struct {
+	ID int `json:"id"`
+	loadbalancerservice.CreateTargetRequest
+}
+
+type listenerSnapshot struct {
+	ID int `json:"id"`
+	loadbalancerservice.CreateListenerRequest
+
+	Binds        []bindSnapshot        `json:"binds"`
+	Certificates []certificateSnapshot `json:"certificates"`
+	AccessIPs    []accessIPSnapshot    `json:"access_ips"`
+	ACLs         []aclSnapshot         `json:"acls"`
+}
+
+type bindSnapshot struct {
+	ID    int `json:"id"`
+	VIPID int `json:"vip_id"`
+	Port  int `json:"port"`
+}
+
+// certificateSnapshot holds certificate metadata only, as key material can't be retrieved from
+// the API
+type certificateSnapshot struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type accessIPSnapshot struct {
+	ID int    `json:"id"`
+	IP string `json:"ip"`
+}
+
+type aclSnapshot struct {
+	ID         int                                `json:"id"`
+	Name       string                             `json:"name"`
+	Priority   int                                `json:"priority"`
+	Conditions []loadbalancerservice.ACLCondition `json:"conditions"`
+	Actions    []loadbalancerservice.ACLAction    `json:"actions"`
+}
+
+// RestoreOptions configures the restoration of a cluster snapshot
+type RestoreOptions struct {
+	// VIPMap maps IDs of VIPs in the snapshot to IDs of VIPs on the target cluster. Every VIP
+	// bound by a listener of the snapshot must be mapped, unless AutoMapVIPs is set
+	VIPMap map[int]int
+
+	// AutoMapVIPs matches bound VIPs which aren't mapped in VIPMap to the remaining VIPs of the
+	// target cluster in order of ID
+	AutoMapVIPs bool
+
+	// CertificateDir is a directory holding the key material of certificates, named by the
+	// certificate ID in the snapshot, as {id}.key, {id}.crt and optionally {id}.ca-bundle
+	CertificateDir string
+}
+
+// newClusterSnapshot returns the snapshot of config. All objects are ordered by ID, so the
+// snapshot of an unchanged cluster is identical
+func newClusterSnapshot(config clusterConfig) clusterSnapshot {
+	snapshot := clusterSnapshot{
+		Version:      clusterSnapshotVersion,
+		ClusterID:    config.Cluster.ID,
+		ClusterName:  config.Cluster.Name,
+		VIPs:         []vipSnapshot{},
+		TargetGroups: []targetGroupSnapshot{},
+		Listeners:    []listenerSnapshot{},
+	}
+
+	for _, vip := range config.VIPs {
+		snapshot.VIPs = append(snapshot.VIPs, vipSnapshot{
+			ID:           vip.ID,
+			InternalCIDR: vip.InternalCIDR,
+			ExternalCIDR: vip.ExternalCIDR,
+		})
+	}
+	sort.Slice(snapshot.VIPs, func(i, j int) bool { return snapshot.VIPs[i].ID < snapshot.VIPs[j].ID })
+
+	for _, targetGroup := range config.TargetGroups {
+		tg := targetGroupSnapshot{
+			ID: targetGroup.ID,
+			CreateTargetGroupRequest: loadbalancerservice.CreateTargetGroupRequest{
+				ClusterID:                targetGroup.ClusterID,
+				Name:                     targetGroup.Name,
+				Balance:                  targetGroup.Balance,
+				Mode:                     targetGroup.Mode,
+				Close:                    targetGroup.Close,
+				Sticky:                   targetGroup.Sticky,
+				CookieOpts:               targetGroup.CookieOpts,
+				Source:                   targetGroup.Source,
+				TimeoutsConnect:          targetGroup.TimeoutsConnect,
+				TimeoutsServer:           targetGroup.TimeoutsServer,
+				TimeoutsHTTPRequest:      targetGroup.TimeoutsHTTPRequest,
+				TimeoutsCheck:            targetGroup.TimeoutsCheck,
+				TimeoutsTunnel:           targetGroup.TimeoutsTunnel,
+				CustomOptions:            targetGroup.CustomOptions,
+				MonitorURL:               targetGroup.MonitorURL,
+				MonitorMethod:            targetGroup.MonitorMethod,
+				MonitorHost:              targetGroup.MonitorHost,
+				MonitorHTTPVersion:       targetGroup.MonitorHTTPVersion,
+				MonitorExpect:            targetGroup.MonitorExpect,
+				MonitorExpectString:      targetGroup.MonitorExpectString,
+				MonitorExpectStringRegex: targetGroup.MonitorExpectStringRegex,
+				MonitorTCPMonitoring:     targetGroup.MonitorTCPMonitoring,
+				CheckPort:                targetGroup.CheckPort,
+				SendProxy:                targetGroup.SendProxy,
+				SendProxyV2:              targetGroup.SendProxyV2,
+				SSL:                      targetGroup.SSL,
+				SSLVerify:                targetGroup.SSLVerify,
+				SNI:                      targetGroup.SNI,
+			},
+			Targets: []targetSnapshot{},
+			ACLs:    newACLSnapshots(targetGroup.ACLs),
+		}
+
+		for _, target := range targetGroup.Targets {
+			tg.Targets = append(tg.Targets, targetSnapshot{
+				ID: target.ID,
+				CreateTargetRequest: loadbalancerservice.CreateTargetRequest{
+					Name:               target.Name,
+					IP:                 target.IP,
+					Port:               target.Port,
+					Weight:             target.Weight,
+					Backup:             target.Backup,
+					CheckInterval:      target.CheckInterval,
+					CheckSSL:           target.CheckSSL,
+					CheckRise:          target.CheckRise,
+					CheckFall:          target.CheckFall,
+					DisableHTTP2:       target.DisableHTTP2,
+					HTTP2Only:          target.HTTP2Only,
+					Active:             target.Active,
+					SessionCookieValue: target.SessionCookieValue,
+				},
+			})
+		}
+		sort.Slice(tg.Targets, func(i, j int) bool { return tg.Targets[i].ID < tg.Targets[j].ID })
+
+		snapshot.TargetGroups = append(snapshot.TargetGroups, tg)
+	}
+	sort.Slice(snapshot.TargetGroups, func(i, j int) bool { return snapshot.TargetGroups[i].ID < snapshot.TargetGroups[j].ID })
+
+	for _, listener := range config.Listeners {
+		l := listenerSnapshot{
+			ID: listener.ID,
+			CreateListenerRequest: loadbalancerservice.CreateListenerRequest{
+				Name:                 listener.Name,
+				ClusterID:            listener.ClusterID,
+				HSTSEnabled:          listener.HSTSEnabled,
+				Mode:                 listener.Mode,
+				HSTSMaxAge:           listener.HSTSMaxAge,
+				Close:                listener.Close,
+				RedirectHTTPS:        listener.RedirectHTTPS,
+				DefaultTargetGroupID: listener.DefaultTargetGroupID,
+				AccessIsAllowList:    listener.AccessIsAllowList,
+				AllowTLSV1:           listener.AllowTLSV1,
+				AllowTLSV11:          listener.AllowTLSV11,
+				DisableTLSV12:        listener.DisableTLSV12,
+				DisableHTTP2:         listener.DisableHTTP2,
+				HTTP2Only:            listener.HTTP2Only,
+				CustomCiphers:        listener.CustomCiphers,
+				CustomOptions:        listener.CustomOptions,
+				TimeoutsClient:       listener.TimeoutsClient,
+			},
+			Binds:        []bindSnapshot{},
+			Certificates: []certificateSnapshot{},
+			AccessIPs:    []accessIPSnapshot{},
+			ACLs:         newACLSnapshots(listener.ACLs),
+		}
+
+		if listener.GeoIP != nil {
+			europeanUnion := listener.GeoIP.EuropeanUnion
+			l.GeoIP = &loadbalancerservice.ListenerGeoIPRequest{
+				Restriction:   listener.GeoIP.Restriction,
+				Continents:    listener.GeoIP.Continents,
+				Countries:     listener.GeoIP.Countries,
+				EuropeanUnion: &europeanUnion,
+			}
+		}
+
+		for _, bind := range listener.Binds {
+			l.Binds = append(l.Binds, bindSnapshot{ID: bind.ID, VIPID: bind.VIPID, Port: bind.Port})
+		}
+		sort.Slice(l.Binds, func(i, j int) bool { return l.Binds[i].ID < l.Binds[j].ID })
+
+		for _, certificate := range listener.Certificates {
+			l.Certificates = append(l.Certificates, certificateSnapshot{
+				ID:        certificate.ID,
+				Name:      certificate.Name,
+				ExpiresAt: certificate.ExpiresAt.String(),
+			})
+		}
+		sort.Slice(l.Certificates, func(i, j int) bool { return l.Certificates[i].ID < l.Certificates[j].ID })
+
+		for _, accessIP := range listener.AccessIPs {
+			l.AccessIPs = append(l.AccessIPs, accessIPSnapshot{ID: accessIP.ID, IP: accessIP.IP.String()})
+		}
+		sort.Slice(l.AccessIPs, func(i, j int) bool { return l.AccessIPs[i].ID < l.AccessIPs[j].ID })
+
+		snapshot.Listeners = append(snapshot.Listeners, l)
+	}
+	sort.Slice(snapshot.Listeners, func(i, j int) bool { return snapshot.Listeners[i].ID < snapshot.Listeners[j].ID })
+
+	return snapshot
+}
+
+func newACLSnapshots(acls []aclWithPriority) []aclSnapshot {
+	snapshots := []aclSnapshot{}
+	for _, acl := range acls {
+		snapshots = append(snapshots, aclSnapshot{
+			ID:         acl.ID,
+			Name:       acl.Name,
+			Priority:   acl.Priority,
+			Conditions: acl.Conditions,
+			Actions:    acl.Actions,
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID < snapshots[j].ID })
+
+	return snapshots
+}
+
+// marshalClusterSnapshot encodes snapshot as indented JSON. Map keys are sorted by the encoder,
+// so the output is stable
+func marshalClusterSnapshot(snapshot clusterSnapshot) (string, error) {
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+func unmarshalClusterSnapshot(b []byte) (clusterSnapshot, error) {
+	var snapshot clusterSnapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return clusterSnapshot{}, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	if snapshot.Version != clusterSnapshotVersion {
+		return clusterSnapshot{}, fmt.Errorf("unsupported snapshot version %d, expected %d", snapshot.Version, clusterSnapshotVersion)
+	}
+
+	return snapshot, nil
+}
+
+// Restore recreates the configuration within a snapshot on the cluster with ID clusterID,
+// which must not have any listeners or target groups. Changes are staged, and not deployed.
+// Warnings are returned for configuration which couldn't be restored, such as certificates
+// without key material
+func Restore(ctx context.Context, apiKey string, snapshot []byte, clusterID int, options RestoreOptions) ([]string, error) {
+	s, err := unmarshalClusterSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreClusterSnapshot(ctx, getService(apiKey), s, clusterID, options)
+}
+
+// clusterRestorer restores a snapshot, tracking the created objects so they can be reported
+// where the restore fails
+type clusterRestorer struct {
+	ctx     context.Context
+	service loadbalancerservice.LoadBalancerService
+	options RestoreOptions
+
+	vips         map[int]int
+	targetGroups map[int]int
+	created      []string
+	warnings     []string
+}
+
+func restoreClusterSnapshot(ctx context.Context, service loadbalancerservice.LoadBalancerService, snapshot clusterSnapshot, clusterID int, options RestoreOptions) ([]string, error) {
+	r := &clusterRestorer{
+		ctx:          ctx,
+		service:      service,
+		options:      options,
+		targetGroups: make(map[int]int),
+	}
+
+	if err := r.prepare(snapshot, clusterID); err != nil {
+		return nil, err
+	}
+
+	if err := r.restore(snapshot, clusterID); err != nil {
+		if len(r.created) > 0 {
+			return r.warnings, fmt.Errorf("%w. Restore is incomplete, and the objects created on cluster with ID [%d] must be removed before retrying: %s", err, clusterID, strings.Join(r.created, ", "))
+		}
+
+		return r.warnings, err
+	}
+
+	return r.warnings, nil
+}
+
+// prepare ensures the target cluster is empty, and maps the VIPs of the snapshot to those of
+// the target cluster
+func (r *clusterRestorer) prepare(snapshot clusterSnapshot, clusterID int) error {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("cluster_id", connection.EQOperator, []string{strconv.Itoa(clusterID)}))
+
+	listeners, err := r.service.GetListeners(params)
+	if err != nil {
+		return fmt.Errorf("error retrieving listeners: %w", err)
+	}
+
+	targetGroups, err := r.service.GetTargetGroups(params)
+	if err != nil {
+		return fmt.Errorf("error retrieving target groups: %w", err)
+	}
+
+	if len(listeners) > 0 || len(targetGroups) > 0 {
+		return fmt.Errorf("cluster with ID [%d] must not have any listeners or target groups", clusterID)
+	}
+
+	vips, err := r.service.GetVIPs(params)
+	if err != nil {
+		return fmt.Errorf("error retrieving VIPs: %w", err)
+	}
+
+	var vipIDs []int
+	for _, vip := range vips {
+		vipIDs = append(vipIDs, vip.ID)
+	}
+
+	r.vips, err = mapSnapshotVIPs(snapshot, vipIDs, r.options)
+
+	return err
+}
+
+// mapSnapshotVIPs maps each VIP bound by a listener of the snapshot to one of vipIDs. Explicitly
+// mapped VIPs are honoured, with the remainder matched in order of ID when automatic mapping is
+// enabled, and rejected otherwise
+func mapSnapshotVIPs(snapshot clusterSnapshot, vipIDs []int, options RestoreOptions) (map[int]int, error) {
+	available := make(map[int]bool)
+	for _, id := range vipIDs {
+		available[id] = true
+	}
+
+	mapped := make(map[int]int)
+	for source, target := range options.VIPMap {
+		if !available[target] {
+			return nil, fmt.Errorf("VIP with ID [%d] doesn't belong to the target cluster", target)
+		}
+
+		mapped[source] = target
+		delete(available, target)
+	}
+
+	bound := make(map[int]bool)
+	for _, listener := range snapshot.Listeners {
+		for _, bind := range listener.Binds {
+			bound[bind.VIPID] = true
+		}
+	}
+
+	var remaining []int
+	for _, id := range vipIDs {
+		if available[id] {
+			remaining = append(remaining, id)
+		}
+	}
+	sort.Ints(remaining)
+
+	var unmapped []string
+	for _, vip := range snapshot.VIPs {
+		if _, ok := mapped[vip.ID]; ok || !bound[vip.ID] {
+			continue
+		}
+
+		if !options.AutoMapVIPs {
+			unmapped = append(unmapped, fmt.Sprintf("%d (%s)", vip.ID, vip.InternalCIDR))
+			continue
+		}
+
+		if len(remaining) == 0 {
+			return nil, fmt.Errorf("target cluster doesn't have a VIP for VIP with ID [%d] (%s) of the snapshot", vip.ID, vip.InternalCIDR)
+		}
+
+		mapped[vip.ID] = remaining[0]
+		remaining = remaining[1:]
+	}
+
+	if len(unmapped) > 0 {
+		return nil, fmt.Errorf("bound VIPs of the snapshot must be mapped to VIPs of the target cluster, or automatic mapping enabled, unmapped VIPs: %s", strings.Join(unmapped, ", "))
+	}
+
+	return mapped, nil
+}
+
+func (r *clusterRestorer) restore(snapshot clusterSnapshot, clusterID int) error {
+	// Target groups are created ahead of their ACLs, which may reference other target groups
+	for _, targetGroup := range snapshot.TargetGroups {
+		req := targetGroup.CreateTargetGroupRequest
+		req.ClusterID = clusterID
+
+		tflog.Info(r.ctx, "restoring target group", map[string]any{
+			"name": req.Name,
+		})
+
+		id, err := r.service.CreateTargetGroup(req)
+		if err != nil {
+			return fmt.Errorf("error creating target group [%s]: %w", req.Name, err)
+		}
+		r.created = append(r.created, fmt.Sprintf("target group with ID [%d]", id))
+		r.targetGroups[targetGroup.ID] = id
+
+		for _, target := range targetGroup.Targets {
+			targetID, err := r.service.CreateTargetGroupTarget(id, target.CreateTargetRequest)
+			if err != nil {
+				return fmt.Errorf("error creating target [%s] of target group [%s]: %w", target.IP, req.Name, err)
+			}
+			r.created = append(r.created, fmt.Sprintf("target with ID [%d] of target group with ID [%d]", targetID, id))
+		}
+	}
+
+	for _, targetGroup := range snapshot.TargetGroups {
+		if err := r.restoreACLs(targetGroup.ACLs, 0, r.targetGroups[targetGroup.ID]); err != nil {
+			return err
+		}
+	}
+
+	for _, listener := range snapshot.Listeners {
+		req := listener.CreateListenerRequest
+		req.ClusterID = clusterID
+
+		if req.DefaultTargetGroupID != 0 {
+			id, ok := r.targetGroups[req.DefaultTargetGroupID]
+			if !ok {
+				return fmt.Errorf("default target group with ID [%d] of listener [%s] isn't within the snapshot", req.DefaultTargetGroupID, req.Name)
+			}
+			req.DefaultTargetGroupID = id
+		}
+
+		tflog.Info(r.ctx, "restoring listener", map[string]any{
+			"name": req.Name,
+		})
+
+		id, err := r.service.CreateListener(req)
+		if err != nil {
+			return fmt.Errorf("error creating listener [%s]: %w", req.Name, err)
+		}
+		r.created = append(r.created, fmt.Sprintf("listener with ID [%d]", id))
+
+		for _, bind := range listener.Binds {
+			bindID, err := r.service.CreateListenerBind(id, loadbalancerservice.CreateBindRequest{
+				VIPID: r.vips[bind.VIPID],
+				Port:  bind.Port,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating bind on port %d of listener [%s]: %w", bind.Port, req.Name, err)
+			}
+			r.created = append(r.created, fmt.Sprintf("bind with ID [%d] of listener with ID [%d]", bindID, id))
+		}
+
+		for _, certificate := range listener.Certificates {
+			if err := r.restoreCertificate(id, req.Name, certificate); err != nil {
+				return err
+			}
+		}
+
+		for _, accessIP := range listener.AccessIPs {
+			accessIPID, err := r.service.CreateListenerAccessIP(id, loadbalancerservice.CreateAccessIPRequest{
+				IP: connection.IPAddress(accessIP.IP),
+			})
+			if err != nil {
+				return fmt.Errorf("error creating access IP [%s] of listener [%s]: %w", accessIP.IP, req.Name, err)
+			}
+			r.created = append(r.created, fmt.Sprintf("access IP with ID [%d] of listener with ID [%d]", accessIPID, id))
+		}
+
+		if err := r.restoreACLs(listener.ACLs, id, 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreCertificate creates a certificate using the key material within the certificate
+// directory. Certificates without key material are skipped with a warning
+func (r *clusterRestorer) restoreCertificate(listenerID int, listenerName string, certificate certificateSnapshot) error {
+	req := loadbalancerservice.CreateCertificateRequest{Name: certificate.Name}
+
+	var missing []error
+	if r.options.CertificateDir == "" {
+		missing = append(missing, errors.New("no certificate directory specified"))
+	} else {
+		for _, file := range []struct {
+			extension string
+			value     *string
+			optional  bool
+		}{
+			{extension: "key", value: &req.Key},
+			{extension: "crt", value: &req.Certificate},
+			{extension: "ca-bundle", value: &req.CABundle, optional: true},
+		} {
+			b, err := os.ReadFile(filepath.Join(r.options.CertificateDir, fmt.Sprintf("%d.%s", certificate.ID, file.extension)))
+			if err != nil {
+				if !file.optional || !errors.Is(err, os.ErrNotExist) {
+					missing = append(missing, err)
+				}
+				continue
+			}
+
+			*file.value = string(b)
+		}
+	}
+
+	if len(missing) > 0 {
+		r.warnings = append(r.warnings, fmt.Sprintf("certificate [%s] with ID [%d] of listener [%s] wasn't restored: %s", certificate.Name, certificate.ID, listenerName, errors.Join(missing...)))
+		return nil
+	}
+
+	certificateID, err := r.service.CreateListenerCertificate(listenerID, req)
+	if err != nil {
+		return fmt.Errorf("error creating certificate [%s] of listener [%s]: %w", certificate.Name, listenerName, err)
+	}
+	r.created = append(r.created, fmt.Sprintf("certificate with ID [%d] of listener with ID [%d]", certificateID, listenerID))
+
+	return nil
+}
+
+func (r *clusterRestorer) restoreACLs(acls []aclSnapshot, listenerID int, targetGroupID int) error {
+	for _, acl := range acls {
+		actions, err := remapACLActions(acl.Actions, r.targetGroups)
+		if err != nil {
+			return fmt.Errorf("error remapping ACL [%s]: %w", acl.Name, err)
+		}
+
+		aclID, err := r.service.CreateACL(loadbalancerservice.CreateACLRequest{
+			Name:          acl.Name,
+			Priority:      acl.Priority,
+			ListenerID:    listenerID,
+			TargetGroupID: targetGroupID,
+			Conditions:    acl.Conditions,
+			Actions:       actions,
+		})
+		if err != nil {
+			return fmt.Errorf("error creating ACL [%s]: %w", acl.Name, err)
+		}
+		r.created = append(r.created, fmt.Sprintf("ACL with ID [%d]", aclID))
+	}
+
+	return nil
+}
+
+// remapACLActions replaces target group IDs within action arguments using targetGroups, which
+// maps snapshot target group IDs to the IDs of target groups on the target cluster
+func remapACLActions(actions []loadbalancerservice.ACLAction, targetGroups map[int]int) ([]loadbalancerservice.ACLAction, error) {
+	var remapped []loadbalancerservice.ACLAction
+	for _, action := range actions {
+		arguments := make(map[string]loadbalancerservice.ACLArgument)
+		for key, argument := range action.Arguments {
+			if argument.Name == "target_group_id" || key == "target_group_id" {
+				id, err := strconv.Atoi(flattenACLArgumentValue(argument.Value))
+				if err != nil {
+					return nil, fmt.Errorf("invalid target group ID [%v]", argument.Value)
+				}
+
+				targetGroupID, ok := targetGroups[id]
+				if !ok {
+					return nil, fmt.Errorf("target group with ID [%d] isn't within the snapshot", id)
+				}
+
+				argument.Value = targetGroupID
+			}
+
+			arguments[key] = argument
+		}
+
+		remapped = append(remapped, loadbalancerservice.ACLAction{Name: action.Name, Arguments: arguments})
+	}
+
+	return remapped, nil
+}
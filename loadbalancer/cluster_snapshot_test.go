@@ -0,0 +1,151 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+func TestClusterSnapshotRoundTrip(t *testing.T) {
+	encoded, err := marshalClusterSnapshot(newClusterSnapshot(testClusterConfig()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	decoded, err := unmarshalClusterSnapshot([]byte(encoded))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	reencoded, err := marshalClusterSnapshot(decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if reencoded != encoded {
+		t.Errorf("snapshot changed after round trip, expected:\n%s\ngot:\n%s", encoded, reencoded)
+	}
+
+	if decoded.Listeners[0].ID != 10 || decoded.Listeners[0].Binds[0].ID != 2 || decoded.Listeners[0].ACLs[0].ID != 30 {
+		t.Errorf("expected objects to be ordered by ID, got %s", encoded)
+	}
+
+	if _, err := unmarshalClusterSnapshot([]byte(`{"version": 2}`)); err == nil || !strings.Contains(err.Error(), "unsupported snapshot version 2") {
+		t.Errorf("expected unsupported version error, got %v", err)
+	}
+}
+
+func TestRestoreClusterSnapshot(t *testing.T) {
+	encoded, err := marshalClusterSnapshot(newClusterSnapshot(testClusterConfig()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	snapshot, err := unmarshalClusterSnapshot([]byte(encoded))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// The snapshot binds to VIPs 1, 2 and 9
+	snapshot.VIPs = append(snapshot.VIPs, vipSnapshot{ID: 9})
+
+	service := newFakeService(t, clusterConfig{
+		VIPs: []loadbalancer.VIP{{ID: 52}, {ID: 51}, {ID: 50}},
+	})
+	service.nextID = 1000
+
+	warnings, err := restoreClusterSnapshot(context.Background(), service, snapshot, 5, RestoreOptions{
+		VIPMap:      map[int]int{9: 50},
+		AutoMapVIPs: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Target groups 20 and 21 are created first, as 1001 and 1004 around the two targets of 20
+	if service.targetGroups[0].ClusterID != 5 || service.listeners[0].ClusterID != 5 {
+		t.Errorf("expected objects to be created on cluster 5")
+	}
+
+	if service.listeners[0].DefaultTargetGroupID != 1001 {
+		t.Errorf("expected default target group to be remapped to 1001, got %d", service.listeners[0].DefaultTargetGroupID)
+	}
+
+	// VIP 1 is matched to the lowest unmapped VIP, and VIP 9 is explicitly mapped
+	expectedVIPs := []int{51, 51, 50, 52}
+	for i, bind := range service.binds {
+		if bind.VIPID != expectedVIPs[i] {
+			t.Errorf("expected bind %d to use VIP %d, got %d", i, expectedVIPs[i], bind.VIPID)
+		}
+	}
+
+	var remapped bool
+	for _, acl := range service.acls {
+		for _, action := range acl.Actions {
+			if action.Name == "use_target_group" {
+				remapped = action.Arguments["target_group_id"].Value == 1004
+			}
+		}
+	}
+	if !remapped {
+		t.Errorf("expected use_target_group action to be remapped to target group 1004, got %#v", service.acls)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "certificate [example.com] with ID [40]") {
+		t.Errorf("expected warning for certificate without key material, got %v", warnings)
+	}
+}
+
+func TestRestoreClusterSnapshotUnmappedVIP(t *testing.T) {
+	snapshot := newClusterSnapshot(testClusterConfig())
+	snapshot.VIPs = append(snapshot.VIPs, vipSnapshot{ID: 9, InternalCIDR: "10.0.0.9/24"})
+
+	service := newFakeService(t, clusterConfig{
+		VIPs: []loadbalancer.VIP{{ID: 52}, {ID: 51}, {ID: 50}},
+	})
+
+	_, err := restoreClusterSnapshot(context.Background(), service, snapshot, 5, RestoreOptions{
+		VIPMap: map[int]int{9: 50},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unmapped VIPs: 1 (10.0.0.5/24), 2 (10.0.0.6/24)") {
+		t.Errorf("expected unmapped VIP error, got %v", err)
+	}
+
+	if len(service.changes) != 0 {
+		t.Errorf("expected no changes, got %v", service.changes)
+	}
+
+	_, err = restoreClusterSnapshot(context.Background(), service, snapshot, 5, RestoreOptions{
+		VIPMap: map[int]int{1: 51, 2: 52, 9: 50},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for i, bind := range service.binds {
+		if expected := map[int]int{443: 51, 80: 51, 8443: 50, 3306: 52}[bind.Port]; bind.VIPID != expected {
+			t.Errorf("expected bind %d to use VIP %d, got %d", i, expected, bind.VIPID)
+		}
+	}
+}
+
+func TestRestoreClusterSnapshotFailure(t *testing.T) {
+	snapshot := newClusterSnapshot(testClusterConfig())
+	snapshot.Listeners = nil
+	snapshot.VIPs = nil
+
+	service := newFakeService(t, clusterConfig{})
+	service.nextID = 1000
+	service.errs = map[string]error{"CreateACL": errors.New("invalid condition")}
+
+	_, err := restoreClusterSnapshot(context.Background(), service, snapshot, 5, RestoreOptions{})
+
+	expected := "objects created on cluster with ID [5] must be removed before retrying: target group with ID [1001], " +
+		"target with ID [1002] of target group with ID [1001], target with ID [1003] of target group with ID [1001], target group with ID [1004]"
+	if err == nil || !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error listing created objects, got %v", err)
+	}
+}
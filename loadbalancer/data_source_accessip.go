@@ -28,6 +28,7 @@ func dataSourceAccessIP() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -35,8 +36,6 @@ func dataSourceAccessIP() *schema.Resource {
 func dataSourceAccessIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
 	listenerID, listenerOk := d.GetOk("listener_id")
 	accessIPID, accessOk := d.GetOk("access_ip_id")
 
@@ -47,14 +46,7 @@ func dataSourceAccessIPRead(ctx context.Context, d *schema.ResourceData, meta in
 	var accessIP loadbalancer.AccessIP
 	var err error
 	if listenerOk {
-		if accessOk {
-			params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(accessIPID.(int))}))
-		}
-		if ip, ok := d.GetOk("ip"); ok {
-			params.WithFilter(*connection.NewAPIRequestFiltering("ip", connection.EQOperator, []string{ip.(string)}))
-		}
-
-		accessIPs, err := service.GetListenerAccessIPs(listenerID.(int), params)
+		accessIPs, err := service.GetListenerAccessIPs(listenerID.(int), dataSourceAccessIPParams(d))
 		if err != nil {
 			return diag.Errorf("Error retrieving access IPs: %s", err)
 		}
@@ -76,7 +68,18 @@ func dataSourceAccessIPRead(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	d.SetId(strconv.Itoa(accessIP.ID))
-	return setKeys(d, map[string]any{
-		"ip": accessIP.IP,
+	return setKeys(d, flattenAccessIP(accessIP))
+}
+
+func dataSourceAccessIPParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"access_ip_id": "id",
+		"ip":           "ip",
 	})
 }
+
+func flattenAccessIP(accessIP loadbalancer.AccessIP) map[string]any {
+	return map[string]any{
+		"ip": accessIP.IP,
+	}
+}
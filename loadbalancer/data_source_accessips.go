@@ -0,0 +1,46 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAccessIPs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAccessIPsRead,
+
+		Schema: dataSourceListSchema(dataSourceAccessIP(), "access_ip_id", "access_ips"),
+	}
+}
+
+func dataSourceAccessIPsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	listenerID, ok := d.GetOk("listener_id")
+	if !ok {
+		return diag.Errorf("listener_id must be provided")
+	}
+
+	params := dataSourceAccessIPParams(d)
+
+	accessIPs, err := service.GetListenerAccessIPs(listenerID.(int), params)
+	if err != nil {
+		return diag.Errorf("Error retrieving access IPs: %s", err)
+	}
+
+	var flattenedAccessIPs []map[string]any
+	for _, accessIP := range accessIPs {
+		flattenedAccessIP := flattenAccessIP(accessIP)
+		flattenedAccessIP["id"] = accessIP.ID
+		flattenedAccessIP["listener_id"] = listenerID
+		flattenedAccessIPs = append(flattenedAccessIPs, flattenedAccessIP)
+	}
+
+	d.SetId(dataSourceListID(params, listenerID.(int)))
+	return setKeys(d, map[string]any{
+		"access_ips": flattenedAccessIPs,
+	})
+}
@@ -5,8 +5,6 @@ import (
 	"strconv"
 
 	"github.com/ans-group/sdk-go/pkg/connection"
-	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
-	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -32,12 +30,47 @@ func dataSourceACL() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"priority": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"condition": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"inverted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"argument": dataSourceACLArgumentSchema(),
+					},
+				},
+			},
+			"action": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"argument": dataSourceACLArgumentSchema(),
+					},
+				},
+			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
 
 func dataSourceACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	service := meta.(loadbalancerservice.LoadBalancerService)
+	service := meta.(aclManager)
 
 	listenerID, listenerOk := d.GetOk("listener_id")
 	targetGroupID, targetGroupOk := d.GetOk("target_group_id")
@@ -46,26 +79,15 @@ func dataSourceACLRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.Errorf("listener_id must be provided when target_group_id is omitted")
 	}
 
-	params := connection.APIRequestParameters{}
-	if aclID, ok := d.GetOk("acl_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(aclID.(int))}))
-	}
-	if name, ok := d.GetOk("name"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
-	}
+	params := dataSourceACLParams(d)
 
-	var acls []loadbalancer.ACL
-	var err error
-	if listenerOk {
-		acls, err = service.GetListenerACLs(listenerID.(int), params)
-		if err != nil {
+	acls, err := service.GetACLsWithPriority(withACLParentFilter(params, listenerID.(int), targetGroupID.(int)))
+	if err != nil {
+		if listenerOk {
 			return diag.Errorf("Error retrieving listener ACLs: %s", err)
 		}
-	} else {
-		acls, err = service.GetTargetGroupACLs(targetGroupID.(int), params)
-		if err != nil {
-			return diag.Errorf("Error retrieving target group ACLs: %s", err)
-		}
+
+		return diag.Errorf("Error retrieving target group ACLs: %s", err)
 	}
 
 	if len(acls) < 1 {
@@ -79,9 +101,67 @@ func dataSourceACLRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	acl := acls[0]
 
 	d.SetId(strconv.Itoa(acl.ID))
-	return setKeys(d, map[string]any{
+	return setKeys(d, flattenACL(acl))
+}
+
+func dataSourceACLParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"acl_id": "id",
+		"name":   "name",
+	})
+}
+
+// dataSourceACLArgumentSchema returns the schema of the arguments of a condition or action.
+// Arguments are listed rather than held in a set, as a set of computed attributes can't be hashed
+func dataSourceACLArgumentSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// withACLParentFilter returns params filtered to the ACLs of the listener, or where listenerID
+// is zero the target group
+func withACLParentFilter(params connection.APIRequestParameters, listenerID int, targetGroupID int) connection.APIRequestParameters {
+	params = params.Copy()
+	if listenerID != 0 {
+		params.WithFilter(*connection.NewAPIRequestFiltering("listener_id", connection.EQOperator, []string{strconv.Itoa(listenerID)}))
+	} else {
+		params.WithFilter(*connection.NewAPIRequestFiltering("target_group_id", connection.EQOperator, []string{strconv.Itoa(targetGroupID)}))
+	}
+
+	return params
+}
+
+func flattenACL(acl aclWithPriority) map[string]any {
+	return map[string]any{
 		"name":            acl.Name,
 		"listener_id":     acl.ListenerID,
 		"target_group_id": acl.TargetGroupID,
-	})
+		"priority":        acl.Priority,
+		"condition":       flattenDataSourceACLRules(flattenACLConditions(acl.Conditions)),
+		"action":          flattenDataSourceACLRules(flattenACLActions(acl.Actions)),
+	}
+}
+
+// flattenDataSourceACLRules converts the argument sets of flattened conditions or actions to the
+// lists of the data source schema
+func flattenDataSourceACLRules(rules []map[string]interface{}) []map[string]interface{} {
+	for _, rule := range rules {
+		rule["argument"] = rule["argument"].(*schema.Set).List()
+	}
+
+	return rules
 }
@@ -0,0 +1,127 @@
+package loadbalancer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceACLTemplates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACLTemplatesRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"conditions": dataSourceACLTemplatesSchema(),
+			"actions":    dataSourceACLTemplatesSchema(),
+		},
+	}
+}
+
+func dataSourceACLTemplatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"friendly_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"arguments": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"description": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"example": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"values": {
+								Type:     schema.TypeList,
+								Computed: true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceACLTemplatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	clusterID := d.Get("cluster_id").(int)
+
+	tflog.Debug(ctx, "retrieving ACL templates", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	templates, err := service.GetClusterACLTemplates(clusterID)
+	if err != nil {
+		return diag.Errorf("Error retrieving ACL templates for cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	var conditions []map[string]interface{}
+	for _, condition := range templates.Conditions {
+		conditions = append(conditions, flattenACLTemplate(condition.Name, condition.FriendlyName, condition.Description, condition.Arguments))
+	}
+
+	var actions []map[string]interface{}
+	for _, action := range templates.Actions {
+		actions = append(actions, flattenACLTemplate(action.Name, action.FriendlyName, action.Description, action.Arguments))
+	}
+
+	d.SetId(strconv.Itoa(clusterID))
+	return setKeys(d, map[string]any{
+		"conditions": conditions,
+		"actions":    actions,
+	})
+}
+
+func flattenACLTemplate(name string, friendlyName string, description string, arguments []loadbalancer.ACLTemplateArgument) map[string]interface{} {
+	var flattenedArguments []map[string]interface{}
+	for _, argument := range arguments {
+		flattenedArguments = append(flattenedArguments, map[string]interface{}{
+			"name":        argument.Name,
+			"description": argument.Description,
+			"example":     flattenACLArgumentValue(argument.Example),
+			"values":      argument.Values,
+		})
+	}
+
+	return map[string]interface{}{
+		"name":          name,
+		"friendly_name": friendlyName,
+		"description":   description,
+		"arguments":     flattenedArguments,
+	}
+}
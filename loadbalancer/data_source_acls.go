@@ -0,0 +1,50 @@
+package loadbalancer
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceACLs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceACLsRead,
+
+		Schema: dataSourceListSchema(dataSourceACL(), "acl_id", "acls"),
+	}
+}
+
+func dataSourceACLsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(aclManager)
+
+	listenerID, listenerOk := d.GetOk("listener_id")
+	targetGroupID, targetGroupOk := d.GetOk("target_group_id")
+
+	if !listenerOk && !targetGroupOk {
+		return diag.Errorf("listener_id must be provided when target_group_id is omitted")
+	}
+
+	params := dataSourceACLParams(d)
+
+	acls, err := service.GetACLsWithPriority(withACLParentFilter(params, listenerID.(int), targetGroupID.(int)))
+	if err != nil {
+		if listenerOk {
+			return diag.Errorf("Error retrieving listener ACLs: %s", err)
+		}
+
+		return diag.Errorf("Error retrieving target group ACLs: %s", err)
+	}
+
+	var flattenedACLs []map[string]any
+	for _, acl := range acls {
+		flattenedACL := flattenACL(acl)
+		flattenedACL["id"] = acl.ID
+		flattenedACLs = append(flattenedACLs, flattenedACL)
+	}
+
+	d.SetId(dataSourceListID(params, listenerID.(int), targetGroupID.(int)))
+	return setKeys(d, map[string]any{
+		"acls": flattenedACLs,
+	})
+}
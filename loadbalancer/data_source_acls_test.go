@@ -0,0 +1,59 @@
+package loadbalancer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceACLsRead(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceACLs().Schema, map[string]interface{}{
+		"listener_id": 1,
+	})
+
+	conn := &testConnection{
+		statusCode: 200,
+		body: `{"data":[
+			{"id":10,"name":"static","listener_id":1,"priority":2,
+				"conditions":[{"name":"path_begins_with","inverted":true,"arguments":{"path":{"name":"path","value":"/static"}}}],
+				"actions":[{"name":"use_target_group","arguments":{"target_group_id":{"name":"target_group_id","value":5}}}]}
+		],"meta":{"pagination":{"total":1,"count":1,"per_page":100,"total_pages":1}}}`,
+	}
+
+	diags := dataSourceACLsRead(context.Background(), d, newProviderService(conn, nil))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"id":              10,
+			"name":            "static",
+			"listener_id":     1,
+			"target_group_id": 0,
+			"priority":        2,
+			"condition": []interface{}{
+				map[string]interface{}{
+					"name":     "path_begins_with",
+					"inverted": true,
+					"argument": []interface{}{map[string]interface{}{"name": "path", "value": "/static"}},
+				},
+			},
+			"action": []interface{}{
+				map[string]interface{}{
+					"name":     "use_target_group",
+					"argument": []interface{}{map[string]interface{}{"name": "target_group_id", "value": "5"}},
+				},
+			},
+		},
+	}
+	if acls := d.Get("acls"); !reflect.DeepEqual(acls, expected) {
+		t.Errorf("expected ACLs %v, got %v", expected, acls)
+	}
+
+	if !reflect.DeepEqual(conn.requests, []string{"GET /loadbalancers/v2/acls"}) {
+		t.Errorf("unexpected requests %v", conn.requests)
+	}
+}
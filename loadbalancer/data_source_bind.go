@@ -31,6 +31,7 @@ func dataSourceBind() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -38,17 +39,7 @@ func dataSourceBind() *schema.Resource {
 func dataSourceBindRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if bindID, ok := d.GetOk("bind_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(bindID.(int))}))
-	}
-	if vipID, ok := d.GetOk("vip_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("vip_id", connection.EQOperator, []string{strconv.Itoa(vipID.(int))}))
-	}
-	if port, ok := d.GetOk("port"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("port", connection.EQOperator, []string{strconv.Itoa(port.(int))}))
-	}
+	params := dataSourceBindParams(d)
 
 	listenerID := d.Get("listener_id")
 
@@ -68,9 +59,21 @@ func dataSourceBindRead(ctx context.Context, d *schema.ResourceData, meta interf
 	bind := binds[0]
 
 	d.SetId(strconv.Itoa(bind.ID))
-	return setKeys(d, map[string]any{
+	return setKeys(d, flattenBind(bind))
+}
+
+func dataSourceBindParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"bind_id": "id",
+		"vip_id":  "vip_id",
+		"port":    "port",
+	})
+}
+
+func flattenBind(bind loadbalancerservice.Bind) map[string]any {
+	return map[string]any{
 		"listener_id": bind.ListenerID,
 		"vip_id":      bind.VIPID,
 		"port":        bind.Port,
-	})
+	}
 }
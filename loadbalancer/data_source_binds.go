@@ -0,0 +1,42 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBinds() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBindsRead,
+
+		Schema: dataSourceListSchema(dataSourceBind(), "bind_id", "binds"),
+	}
+}
+
+func dataSourceBindsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceBindParams(d)
+
+	listenerID := d.Get("listener_id").(int)
+
+	binds, err := service.GetListenerBinds(listenerID, params)
+	if err != nil {
+		return diag.Errorf("Error retrieving binds: %s", err)
+	}
+
+	var flattenedBinds []map[string]any
+	for _, bind := range binds {
+		flattenedBind := flattenBind(bind)
+		flattenedBind["id"] = bind.ID
+		flattenedBinds = append(flattenedBinds, flattenedBind)
+	}
+
+	d.SetId(dataSourceListID(params, listenerID))
+	return setKeys(d, map[string]any{
+		"binds": flattenedBinds,
+	})
+}
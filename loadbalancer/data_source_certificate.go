@@ -3,6 +3,7 @@ package loadbalancer
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/ans-group/sdk-go/pkg/connection"
 	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
@@ -27,6 +28,15 @@ func dataSourceCertificate() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"not_after": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"days_until_expiry": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -34,14 +44,7 @@ func dataSourceCertificate() *schema.Resource {
 func dataSourceCertificateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if certificateID, ok := d.GetOk("certificate_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(certificateID.(int))}))
-	}
-	if name, ok := d.GetOk("name"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
-	}
+	params := dataSourceCertificateParams(d)
 
 	listenerID := d.Get("listener_id")
 
@@ -61,8 +64,31 @@ func dataSourceCertificateRead(ctx context.Context, d *schema.ResourceData, meta
 	certificate := certificates[0]
 
 	d.SetId(strconv.Itoa(certificate.ID))
-	return setKeys(d, map[string]any{
+	return setKeys(d, flattenCertificate(ctx, certificate))
+}
+
+func dataSourceCertificateParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"certificate_id": "id",
+		"name":           "name",
+	})
+}
+
+// flattenCertificate returns the attributes of a certificate. The API doesn't return the PEM
+// encoded certificate, so its expiry is taken from the API rather than parsed. Where the expiry
+// can't be parsed, days_until_expiry is left unset rather than reporting the certificate as
+// expiring
+func flattenCertificate(ctx context.Context, certificate loadbalancerservice.Certificate) map[string]any {
+	flattened := map[string]any{
 		"listener_id": certificate.ListenerID,
 		"name":        certificate.Name,
-	})
+		"not_after":   "",
+	}
+
+	if expiresAt, ok := parseAPIDateTime(ctx, certificate.ExpiresAt); ok {
+		flattened["not_after"] = expiresAt.UTC().Format(time.RFC3339)
+		flattened["days_until_expiry"] = daysUntil(expiresAt)
+	}
+
+	return flattened
 }
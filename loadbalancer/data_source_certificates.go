@@ -0,0 +1,42 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCertificates() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCertificatesRead,
+
+		Schema: dataSourceListSchema(dataSourceCertificate(), "certificate_id", "certificates"),
+	}
+}
+
+func dataSourceCertificatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceCertificateParams(d)
+
+	listenerID := d.Get("listener_id").(int)
+
+	certificates, err := service.GetListenerCertificates(listenerID, params)
+	if err != nil {
+		return diag.Errorf("Error retrieving certificates: %s", err)
+	}
+
+	var flattenedCertificates []map[string]any
+	for _, certificate := range certificates {
+		flattenedCertificate := flattenCertificate(ctx, certificate)
+		flattenedCertificate["id"] = certificate.ID
+		flattenedCertificates = append(flattenedCertificates, flattenedCertificate)
+	}
+
+	d.SetId(dataSourceListID(params, listenerID))
+	return setKeys(d, map[string]any{
+		"certificates": flattenedCertificates,
+	})
+}
@@ -27,6 +27,27 @@ func dataSourceCluster() *schema.Resource {
 				Type:     schema.TypeBool,
 				Optional: true,
 			},
+			"deployed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_deployment_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_deployment_successful": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -34,17 +55,7 @@ func dataSourceCluster() *schema.Resource {
 func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if id, ok := d.GetOk("cluster_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(id.(int))}))
-	}
-	if name, ok := d.GetOk("name"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
-	}
-	if deployed, ok := d.GetOk("deployed"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("deployed", connection.EQOperator, []string{strconv.FormatBool(deployed.(bool))}))
-	}
+	params := dataSourceClusterParams(d)
 
 	clusters, err := service.GetClusters(params)
 	if err != nil {
@@ -59,9 +70,19 @@ func dataSourceClusterRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("More than 1 cluster found with provided arguments")
 	}
 
+	deployment, err := getLatestClusterDeployment(service, clusters[0].ID)
+	if err != nil {
+		return diag.Errorf("Error retrieving deployments for cluster with ID [%d]: %s", clusters[0].ID, err)
+	}
+
 	d.SetId(strconv.Itoa(clusters[0].ID))
-	return setKeys(d, map[string]any{
-		"name":     clusters[0].Name,
-		"deployed": clusters[0].Deployed,
+	return setKeys(d, flattenCluster(clusters[0], deployment))
+}
+
+func dataSourceClusterParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"cluster_id": "id",
+		"name":       "name",
+		"deployed":   "deployed",
 	})
 }
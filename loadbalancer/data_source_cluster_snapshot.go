@@ -0,0 +1,57 @@
+package loadbalancer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceClusterSnapshot() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterSnapshotRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceClusterSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(clusterConfigService)
+
+	clusterID := d.Get("cluster_id").(int)
+
+	tflog.Debug(ctx, "retrieving staged configuration", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	config, err := getClusterConfig(service, clusterID)
+	if err != nil {
+		return diag.Errorf("Error retrieving configuration for cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	snapshot, err := marshalClusterSnapshot(newClusterSnapshot(config))
+	if err != nil {
+		return diag.Errorf("Error encoding snapshot of cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	d.SetId(strconv.Itoa(clusterID))
+	return setKeys(d, map[string]any{
+		"version": clusterSnapshotVersion,
+		"json":    snapshot,
+	})
+}
@@ -0,0 +1,89 @@
+package loadbalancer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceClusterValidation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClusterValidationRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"fail_on_invalid": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"valid": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"warnings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceClusterValidationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	clusterID := d.Get("cluster_id").(int)
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(clusterID)}))
+
+	clusters, err := service.GetClusters(params)
+	if err != nil {
+		return diag.Errorf("Error retrieving clusters: %s", err)
+	}
+
+	if len(clusters) < 1 {
+		return diag.Errorf("No clusters found with provided arguments")
+	}
+
+	validation, err := meta.(clusterValidator).validateCluster(clusterID)
+	if err != nil {
+		return diag.Errorf("Error validating cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	d.SetId(strconv.Itoa(clusterID))
+	diags := setKeys(d, map[string]any{
+		"valid":    validation.Valid,
+		"errors":   validation.Errors,
+		"warnings": validation.Warnings,
+	})
+
+	if !validation.Valid && d.Get("fail_on_invalid").(bool) {
+		for _, validationError := range validation.Errors {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Cluster configuration is invalid",
+				Detail:   validationError,
+			})
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,50 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceClusters() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceClustersRead,
+
+		Schema: dataSourceListSchema(dataSourceCluster(), "cluster_id", "clusters"),
+	}
+}
+
+func dataSourceClustersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceClusterParams(d)
+
+	clusters, err := service.GetClusters(params)
+	if err != nil {
+		return diag.Errorf("Error retrieving clusters: %s", err)
+	}
+
+	var clusterIDs []int
+	for _, cluster := range clusters {
+		clusterIDs = append(clusterIDs, cluster.ID)
+	}
+
+	deployments, err := getLatestClusterDeployments(service, clusterIDs)
+	if err != nil {
+		return diag.Errorf("Error retrieving deployments: %s", err)
+	}
+
+	var flattenedClusters []map[string]any
+	for _, cluster := range clusters {
+		flattenedCluster := flattenCluster(cluster, deployments[cluster.ID])
+		flattenedCluster["id"] = cluster.ID
+		flattenedClusters = append(flattenedClusters, flattenedCluster)
+	}
+
+	d.SetId(dataSourceListID(params))
+	return setKeys(d, map[string]any{
+		"clusters": flattenedClusters,
+	})
+}
@@ -0,0 +1,84 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// dataSourceFilterSchema returns the schema for the repeatable filter block, which is available
+// on every data source
+func dataSourceFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"property": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      connection.EQOperator.String(),
+					ValidateFunc: validation.StringInSlice(connection.APIRequestFilteringOperatorEnum.Values(), true),
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceParams returns the API request parameters for a data source. An equality filter is
+// added for each set argument in arguments, which maps argument names to API properties, followed
+// by a filter for each filter block
+func dataSourceParams(d *schema.ResourceData, arguments map[string]string) connection.APIRequestParameters {
+	params := connection.APIRequestParameters{}
+
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if value, ok := d.GetOk(name); ok {
+			params.WithFilter(*connection.NewAPIRequestFiltering(arguments[name], connection.EQOperator, []string{fmt.Sprint(value)}))
+		}
+	}
+
+	params.WithFilter(expandDataSourceFilters(d.Get("filter").([]interface{}))...)
+
+	return params
+}
+
+func expandDataSourceFilters(rawFilters []interface{}) []connection.APIRequestFiltering {
+	var filters []connection.APIRequestFiltering
+	for _, rawFilter := range rawFilters {
+		filter := rawFilter.(map[string]interface{})
+
+		// Operator is validated against the same enum by the schema
+		operator, _ := connection.APIRequestFilteringOperatorEnum.Parse(filter["operator"].(string))
+
+		var values []string
+		for _, value := range filter["values"].([]interface{}) {
+			values = append(values, value.(string))
+		}
+
+		filters = append(filters, *connection.NewAPIRequestFiltering(strings.TrimSpace(filter["property"].(string)), operator, values))
+	}
+
+	return filters
+}
@@ -0,0 +1,83 @@
+package loadbalancer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceParams(t *testing.T) {
+	arguments := map[string]string{
+		"vip_id":     "id",
+		"cluster_id": "cluster_id",
+	}
+
+	tests := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected []connection.APIRequestFiltering
+	}{
+		{
+			name: "no arguments",
+			raw:  map[string]interface{}{},
+		},
+		{
+			name: "arguments in order of name",
+			raw:  map[string]interface{}{"vip_id": 5, "cluster_id": 1},
+			expected: []connection.APIRequestFiltering{
+				{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{"1"}},
+				{Property: "id", Operator: connection.EQOperator, Value: []string{"5"}},
+			},
+		},
+		{
+			name: "multiple filters follow arguments",
+			raw: map[string]interface{}{
+				"cluster_id": 1,
+				"filter": []interface{}{
+					map[string]interface{}{"property": "internal_cidr", "operator": "lk", "values": []interface{}{"10.0.*"}},
+					map[string]interface{}{"property": "mac_address", "values": []interface{}{"00:00:5e:00:01:01"}},
+				},
+			},
+			expected: []connection.APIRequestFiltering{
+				{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{"1"}},
+				{Property: "internal_cidr", Operator: connection.LKOperator, Value: []string{"10.0.*"}},
+				{Property: "mac_address", Operator: connection.EQOperator, Value: []string{"00:00:5e:00:01:01"}},
+			},
+		},
+		{
+			name: "multiple values",
+			raw: map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"property": "id", "operator": "in", "values": []interface{}{"5", "6", "7"}},
+				},
+			},
+			expected: []connection.APIRequestFiltering{
+				{Property: "id", Operator: connection.INOperator, Value: []string{"5", "6", "7"}},
+			},
+		},
+		{
+			name: "unknown property is passed to the API",
+			raw: map[string]interface{}{
+				"filter": []interface{}{
+					map[string]interface{}{"property": " not_a_property ", "operator": "NEQ", "values": []interface{}{"x"}},
+				},
+			},
+			expected: []connection.APIRequestFiltering{
+				{Property: "not_a_property", Operator: connection.NEQOperator, Value: []string{"x"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceVip().Schema, tc.raw)
+
+			params := dataSourceParams(d, arguments)
+			if !reflect.DeepEqual(params.Filtering, tc.expected) {
+				t.Errorf("expected filters %+v, got %+v", tc.expected, params.Filtering)
+			}
+		})
+	}
+}
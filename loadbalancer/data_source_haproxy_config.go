@@ -0,0 +1,47 @@
+package loadbalancer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHAProxyConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHAProxyConfigRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"config": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceHAProxyConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(clusterConfigService)
+
+	clusterID := d.Get("cluster_id").(int)
+
+	tflog.Debug(ctx, "retrieving staged configuration", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	config, err := getClusterConfig(service, clusterID)
+	if err != nil {
+		return diag.Errorf("Error retrieving configuration for cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	d.SetId(strconv.Itoa(clusterID))
+	return setKeys(d, map[string]any{
+		"config": renderHAProxyConfig(config),
+	})
+}
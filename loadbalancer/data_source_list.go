@@ -0,0 +1,61 @@
+package loadbalancer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceListSchema derives the schema of a plural data source from its singular counterpart.
+// The arguments of the singular data source are retained as filters, and each matching object
+// is returned within listKey, with idKey replaced by id
+func dataSourceListSchema(singular *schema.Resource, idKey string, listKey string) map[string]*schema.Schema {
+	elem := map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+
+	listSchema := map[string]*schema.Schema{
+		listKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: elem,
+			},
+		},
+	}
+
+	for k, v := range singular.Schema {
+		if v.Required || v.Optional {
+			listSchema[k] = &schema.Schema{
+				Type:     v.Type,
+				Required: v.Required,
+				Optional: v.Optional,
+				Elem:     v.Elem,
+			}
+		}
+
+		if k != idKey && k != "filter" {
+			elem[k] = &schema.Schema{
+				Type:     v.Type,
+				Computed: true,
+				Elem:     v.Elem,
+			}
+		}
+	}
+
+	return listSchema
+}
+
+// dataSourceListID returns a stable ID for a plural data source, derived from the filters and
+// parent IDs it was read with
+func dataSourceListID(params connection.APIRequestParameters, parentIDs ...int) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%v/%v", parentIDs, params.Filtering)))
+
+	return hex.EncodeToString(hash[:])
+}
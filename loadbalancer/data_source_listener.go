@@ -75,6 +75,7 @@ func dataSourceListener() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -82,19 +83,7 @@ func dataSourceListener() *schema.Resource {
 func dataSourceListenerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if id, ok := d.GetOk("listener_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(id.(int))}))
-	}
-	if name, ok := d.GetOk("name"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
-	}
-	if clusterID, ok := d.GetOk("cluster_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("cluster_id", connection.EQOperator, []string{strconv.Itoa(clusterID.(int))}))
-	}
-
-	listeners, err := service.GetListeners(params)
+	listeners, err := service.GetListeners(dataSourceListenerParams(d))
 	if err != nil {
 		return diag.Errorf("Error retrieving listeners: %s", err)
 	}
@@ -109,19 +98,32 @@ func dataSourceListenerRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(strconv.Itoa(listeners[0].ID))
 
-	return setKeys(d, map[string]any{
-		"name":                    listeners[0].Name,
-		"cluster_id":              listeners[0].ClusterID,
-		"hsts_enabled":            listeners[0].HSTSEnabled,
-		"mode":                    listeners[0].Mode,
-		"hsts_maxage":             listeners[0].HSTSMaxAge,
-		"close":                   listeners[0].Close,
-		"redirect_https":          listeners[0].RedirectHTTPS,
-		"default_target_group_id": listeners[0].DefaultTargetGroupID,
-		"allow_tlsv1":             listeners[0].AllowTLSV1,
-		"allow_tlsv11":            listeners[0].AllowTLSV11,
-		"disable_tlsv12":          listeners[0].DisableTLSV12,
-		"disable_http2":           listeners[0].DisableHTTP2,
-		"http2_only":              listeners[0].HTTP2Only,
+	return setKeys(d, flattenListener(listeners[0]))
+}
+
+func dataSourceListenerParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"listener_id": "id",
+		"name":        "name",
+		"cluster_id":  "cluster_id",
 	})
 }
+
+func flattenListener(listener loadbalancerservice.Listener) map[string]any {
+	return map[string]any{
+		"name":                    listener.Name,
+		"cluster_id":              listener.ClusterID,
+		"hsts_enabled":            listener.HSTSEnabled,
+		"mode":                    listener.Mode,
+		"hsts_maxage":             listener.HSTSMaxAge,
+		"close":                   listener.Close,
+		"redirect_https":          listener.RedirectHTTPS,
+		"default_target_group_id": listener.DefaultTargetGroupID,
+		"allow_tlsv1":             listener.AllowTLSV1,
+		"allow_tlsv11":            listener.AllowTLSV11,
+		"disable_tlsv12":          listener.DisableTLSV12,
+		"disable_http2":           listener.DisableHTTP2,
+		"http2_only":              listener.HTTP2Only,
+		"custom_ciphers":          listener.CustomCiphers,
+	}
+}
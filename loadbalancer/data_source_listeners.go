@@ -0,0 +1,40 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceListeners() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceListenersRead,
+
+		Schema: dataSourceListSchema(dataSourceListener(), "listener_id", "listeners"),
+	}
+}
+
+func dataSourceListenersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceListenerParams(d)
+
+	listeners, err := service.GetListeners(params)
+	if err != nil {
+		return diag.Errorf("Error retrieving listeners: %s", err)
+	}
+
+	var flattenedListeners []map[string]any
+	for _, listener := range listeners {
+		flattenedListener := flattenListener(listener)
+		flattenedListener["id"] = listener.ID
+		flattenedListeners = append(flattenedListeners, flattenedListener)
+	}
+
+	d.SetId(dataSourceListID(params))
+	return setKeys(d, map[string]any{
+		"listeners": flattenedListeners,
+	})
+}
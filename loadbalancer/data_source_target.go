@@ -71,6 +71,7 @@ func dataSourceTarget() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -78,20 +79,7 @@ func dataSourceTarget() *schema.Resource {
 func dataSourceTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if id, ok := d.GetOk("target_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(id.(int))}))
-	}
-	if name, ok := d.GetOk("name"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
-	}
-	if ip, ok := d.GetOk("ip"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("ip", connection.EQOperator, []string{ip.(string)}))
-	}
-	if port, ok := d.GetOk("port"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("port", connection.EQOperator, []string{strconv.Itoa(port.(int))}))
-	}
+	params := dataSourceTargetParams(d)
 
 	targetgroupID := d.Get("target_group_id").(int)
 
@@ -109,19 +97,32 @@ func dataSourceTargetRead(ctx context.Context, d *schema.ResourceData, meta inte
 	}
 
 	d.SetId(strconv.Itoa(targets[0].ID))
-	return setKeys(d, map[string]any{
-		"target_group_id": targets[0].TargetGroupID,
-		"name":            targets[0].Name,
-		"ip":              targets[0].IP,
-		"port":            targets[0].Port,
-		"weight":          targets[0].Weight,
-		"backup":          targets[0].Backup,
-		"check_interval":  targets[0].CheckInterval,
-		"check_ssl":       targets[0].CheckSSL,
-		"check_rise":      targets[0].CheckRise,
-		"check_fall":      targets[0].CheckFall,
-		"disable_http2":   targets[0].DisableHTTP2,
-		"http2_only":      targets[0].HTTP2Only,
-		"active":          targets[0].Active,
+	return setKeys(d, flattenTarget(targets[0]))
+}
+
+func dataSourceTargetParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"target_id": "id",
+		"name":      "name",
+		"ip":        "ip",
+		"port":      "port",
 	})
 }
+
+func flattenTarget(target loadbalancerservice.Target) map[string]any {
+	return map[string]any{
+		"target_group_id": target.TargetGroupID,
+		"name":            target.Name,
+		"ip":              target.IP,
+		"port":            target.Port,
+		"weight":          target.Weight,
+		"backup":          target.Backup,
+		"check_interval":  target.CheckInterval,
+		"check_ssl":       target.CheckSSL,
+		"check_rise":      target.CheckRise,
+		"check_fall":      target.CheckFall,
+		"disable_http2":   target.DisableHTTP2,
+		"http2_only":      target.HTTP2Only,
+		"active":          target.Active,
+	}
+}
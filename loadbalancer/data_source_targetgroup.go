@@ -111,6 +111,7 @@ func dataSourceTargetGroup() *schema.Resource {
 				Type:     schema.TypeBool,
 				Computed: true,
 			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -118,17 +119,7 @@ func dataSourceTargetGroup() *schema.Resource {
 func dataSourceTargetGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if id, ok := d.GetOk("target_group_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(id.(int))}))
-	}
-	if name, ok := d.GetOk("name"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("name", connection.EQOperator, []string{name.(string)}))
-	}
-	if clusterID, ok := d.GetOk("cluster_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("cluster_id", connection.EQOperator, []string{strconv.Itoa(clusterID.(int))}))
-	}
+	params := dataSourceTargetGroupParams(d)
 
 	targetgroups, err := service.GetTargetGroups(params)
 	if err != nil {
@@ -144,29 +135,41 @@ func dataSourceTargetGroupRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	d.SetId(strconv.Itoa(targetgroups[0].ID))
-	return setKeys(d, map[string]any{
-		"name":                   targetgroups[0].Name,
-		"cluster_id":             targetgroups[0].ClusterID,
-		"balance":                targetgroups[0].Balance,
-		"mode":                   targetgroups[0].Mode,
-		"close":                  targetgroups[0].Close,
-		"sticky":                 targetgroups[0].Sticky,
-		"cookie_opts":            targetgroups[0].CookieOpts,
-		"source":                 targetgroups[0].Source,
-		"timeouts_connect":       targetgroups[0].TimeoutsConnect,
-		"timeouts_server":        targetgroups[0].TimeoutsServer,
-		"custom_options":         targetgroups[0].CustomOptions,
-		"monitor_url":            targetgroups[0].MonitorURL,
-		"monitor_method":         targetgroups[0].MonitorMethod,
-		"monitor_host":           targetgroups[0].MonitorHost,
-		"monitor_http_version":   targetgroups[0].MonitorHTTPVersion,
-		"monitor_expect":         targetgroups[0].MonitorExpect,
-		"monitor_tcp_monitoring": targetgroups[0].MonitorTCPMonitoring,
-		"check_port":             targetgroups[0].CheckPort,
-		"send_proxy":             targetgroups[0].SendProxy,
-		"send_proxy_v2":          targetgroups[0].SendProxyV2,
-		"ssl":                    targetgroups[0].SSL,
-		"ssl_verify":             targetgroups[0].SSLVerify,
-		"sni":                    targetgroups[0].SNI,
+	return setKeys(d, flattenTargetGroup(targetgroups[0]))
+}
+
+func dataSourceTargetGroupParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"target_group_id": "id",
+		"name":            "name",
+		"cluster_id":      "cluster_id",
 	})
 }
+
+func flattenTargetGroup(targetGroup loadbalancerservice.TargetGroup) map[string]any {
+	return map[string]any{
+		"name":                   targetGroup.Name,
+		"cluster_id":             targetGroup.ClusterID,
+		"balance":                targetGroup.Balance,
+		"mode":                   targetGroup.Mode,
+		"close":                  targetGroup.Close,
+		"sticky":                 targetGroup.Sticky,
+		"cookie_opts":            targetGroup.CookieOpts,
+		"source":                 targetGroup.Source,
+		"timeouts_connect":       targetGroup.TimeoutsConnect,
+		"timeouts_server":        targetGroup.TimeoutsServer,
+		"custom_options":         targetGroup.CustomOptions,
+		"monitor_url":            targetGroup.MonitorURL,
+		"monitor_method":         targetGroup.MonitorMethod,
+		"monitor_host":           targetGroup.MonitorHost,
+		"monitor_http_version":   targetGroup.MonitorHTTPVersion,
+		"monitor_expect":         targetGroup.MonitorExpect,
+		"monitor_tcp_monitoring": targetGroup.MonitorTCPMonitoring,
+		"check_port":             targetGroup.CheckPort,
+		"send_proxy":             targetGroup.SendProxy,
+		"send_proxy_v2":          targetGroup.SendProxyV2,
+		"ssl":                    targetGroup.SSL,
+		"ssl_verify":             targetGroup.SSLVerify,
+		"sni":                    targetGroup.SNI,
+	}
+}
@@ -0,0 +1,40 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTargetGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTargetGroupsRead,
+
+		Schema: dataSourceListSchema(dataSourceTargetGroup(), "target_group_id", "target_groups"),
+	}
+}
+
+func dataSourceTargetGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceTargetGroupParams(d)
+
+	targetGroups, err := service.GetTargetGroups(params)
+	if err != nil {
+		return diag.Errorf("Error retrieving target groups: %s", err)
+	}
+
+	var flattenedTargetGroups []map[string]any
+	for _, targetGroup := range targetGroups {
+		flattenedTargetGroup := flattenTargetGroup(targetGroup)
+		flattenedTargetGroup["id"] = targetGroup.ID
+		flattenedTargetGroups = append(flattenedTargetGroups, flattenedTargetGroup)
+	}
+
+	d.SetId(dataSourceListID(params))
+	return setKeys(d, map[string]any{
+		"target_groups": flattenedTargetGroups,
+	})
+}
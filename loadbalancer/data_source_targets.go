@@ -0,0 +1,42 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTargets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTargetsRead,
+
+		Schema: dataSourceListSchema(dataSourceTarget(), "target_id", "targets"),
+	}
+}
+
+func dataSourceTargetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceTargetParams(d)
+
+	targetGroupID := d.Get("target_group_id").(int)
+
+	targets, err := service.GetTargetGroupTargets(targetGroupID, params)
+	if err != nil {
+		return diag.Errorf("Error retrieving targets: %s", err)
+	}
+
+	var flattenedTargets []map[string]any
+	for _, target := range targets {
+		flattenedTarget := flattenTarget(target)
+		flattenedTarget["id"] = target.ID
+		flattenedTargets = append(flattenedTargets, flattenedTarget)
+	}
+
+	d.SetId(dataSourceListID(params, targetGroupID))
+	return setKeys(d, map[string]any{
+		"targets": flattenedTargets,
+	})
+}
@@ -17,24 +17,29 @@ func dataSourceVip() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"vip_id": {
 				Type:     schema.TypeInt,
-				Required: true,
+				Optional: true,
 			},
 			"cluster_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"internal_cidr": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"external_cidr": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
 			"mac_address": {
 				Type:     schema.TypeString,
 				Optional: true,
+				Computed: true,
 			},
+			"filter": dataSourceFilterSchema(),
 		},
 	}
 }
@@ -42,11 +47,7 @@ func dataSourceVip() *schema.Resource {
 func dataSourceVipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
-	params := connection.APIRequestParameters{}
-
-	if id, ok := d.GetOk("vip_id"); ok {
-		params.WithFilter(*connection.NewAPIRequestFiltering("id", connection.EQOperator, []string{strconv.Itoa(id.(int))}))
-	}
+	params := dataSourceVipParams(d)
 
 	vips, err := service.GetVIPs(params)
 	if err != nil {
@@ -62,10 +63,24 @@ func dataSourceVipRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 
 	d.SetId(strconv.Itoa(vips[0].ID))
-	return setKeys(d, map[string]any{
-		"cluster_id":    vips[0].ClusterID,
-		"internal_cidr": vips[0].InternalCIDR,
-		"external_cidr": vips[0].ExternalCIDR,
-		"mac_address":   vips[0].MACAddress,
+	return setKeys(d, flattenVIP(vips[0]))
+}
+
+func dataSourceVipParams(d *schema.ResourceData) connection.APIRequestParameters {
+	return dataSourceParams(d, map[string]string{
+		"vip_id":        "id",
+		"cluster_id":    "cluster_id",
+		"internal_cidr": "internal_cidr",
+		"external_cidr": "external_cidr",
+		"mac_address":   "mac_address",
 	})
 }
+
+func flattenVIP(vip loadbalancerservice.VIP) map[string]any {
+	return map[string]any{
+		"cluster_id":    vip.ClusterID,
+		"internal_cidr": vip.InternalCIDR,
+		"external_cidr": vip.ExternalCIDR,
+		"mac_address":   vip.MACAddress,
+	}
+}
@@ -0,0 +1,59 @@
+package loadbalancer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceVipParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      map[string]interface{}
+		expected []connection.APIRequestFiltering
+	}{
+		{
+			name: "cluster",
+			raw:  map[string]interface{}{"cluster_id": 1},
+			expected: []connection.APIRequestFiltering{
+				{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{"1"}},
+			},
+		},
+		{
+			name: "CIDRs",
+			raw:  map[string]interface{}{"internal_cidr": "10.0.0.5/24", "external_cidr": "203.0.113.5/32"},
+			expected: []connection.APIRequestFiltering{
+				{Property: "external_cidr", Operator: connection.EQOperator, Value: []string{"203.0.113.5/32"}},
+				{Property: "internal_cidr", Operator: connection.EQOperator, Value: []string{"10.0.0.5/24"}},
+			},
+		},
+		{
+			name: "MAC address",
+			raw:  map[string]interface{}{"mac_address": "00:00:5e:00:01:01"},
+			expected: []connection.APIRequestFiltering{
+				{Property: "mac_address", Operator: connection.EQOperator, Value: []string{"00:00:5e:00:01:01"}},
+			},
+		},
+		{
+			name: "ID with cluster",
+			raw:  map[string]interface{}{"vip_id": 5, "cluster_id": 1},
+			expected: []connection.APIRequestFiltering{
+				{Property: "cluster_id", Operator: connection.EQOperator, Value: []string{"1"}},
+				{Property: "id", Operator: connection.EQOperator, Value: []string{"5"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceVip().Schema, tc.raw)
+
+			params := dataSourceVipParams(d)
+			if !reflect.DeepEqual(params.Filtering, tc.expected) {
+				t.Errorf("expected filters %+v, got %+v", tc.expected, params.Filtering)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package loadbalancer
+
+import (
+	"context"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVips() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVipsRead,
+
+		Schema: dataSourceListSchema(dataSourceVip(), "vip_id", "vips"),
+	}
+}
+
+func dataSourceVipsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	params := dataSourceVipParams(d)
+
+	vips, err := service.GetVIPs(params)
+	if err != nil {
+		return diag.Errorf("Error retrieving vips: %s", err)
+	}
+
+	var flattenedVips []map[string]any
+	for _, vip := range vips {
+		flattenedVip := flattenVIP(vip)
+		flattenedVip["id"] = vip.ID
+		flattenedVips = append(flattenedVips, flattenedVip)
+	}
+
+	d.SetId(dataSourceListID(params))
+	return setKeys(d, map[string]any{
+		"vips": flattenedVips,
+	})
+}
@@ -0,0 +1,124 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+const (
+	deploymentStatePending    = "pending"
+	deploymentStateSuccessful = "successful"
+)
+
+// deployCluster triggers a deployment of the staged configuration for the given cluster, and
+// waits for the resulting deployment to be recorded against the cluster
+func deployCluster(ctx context.Context, service loadbalancerservice.LoadBalancerService, clusterID int, timeout time.Duration) (loadbalancerservice.Deployment, error) {
+	previous, err := getLatestClusterDeployment(service, clusterID)
+	if err != nil {
+		return loadbalancerservice.Deployment{}, fmt.Errorf("Error retrieving deployments for cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	tflog.Info(ctx, "deploying cluster", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	err = service.DeployCluster(clusterID)
+	if err != nil {
+		return loadbalancerservice.Deployment{}, fmt.Errorf("Error deploying cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:    []string{deploymentStatePending},
+		Target:     []string{deploymentStateSuccessful},
+		Refresh:    clusterDeploymentStateRefreshFunc(service, clusterID, previous.ID),
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	deployment, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return loadbalancerservice.Deployment{}, fmt.Errorf("Error waiting for deployment of cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	return deployment.(loadbalancerservice.Deployment), nil
+}
+
+// clusterDeploymentStateRefreshFunc returns a retry.StateRefreshFunc which reports the state of
+// the first deployment recorded against the cluster after previousID
+func clusterDeploymentStateRefreshFunc(service loadbalancerservice.LoadBalancerService, clusterID int, previousID int) retry.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		deployment, err := getLatestClusterDeployment(service, clusterID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if deployment.ID <= previousID {
+			return deployment, deploymentStatePending, nil
+		}
+
+		if !deployment.Successful {
+			return nil, "", fmt.Errorf("deployment with ID [%d] failed (PSS ID [%d])", deployment.ID, deployment.PSSID)
+		}
+
+		return deployment, deploymentStateSuccessful, nil
+	}
+}
+
+// getLatestClusterDeployment returns the most recent deployment for the given cluster, or an
+// empty Deployment if the cluster has never been deployed
+func getLatestClusterDeployment(service loadbalancerservice.LoadBalancerService, clusterID int) (loadbalancerservice.Deployment, error) {
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("cluster_id", connection.EQOperator, []string{strconv.Itoa(clusterID)}))
+	params.WithSorting(connection.APIRequestSorting{Property: "id", Descending: true})
+	params.WithPagination(connection.APIRequestPagination{PerPage: 1, Page: 1})
+
+	deployments, err := service.GetDeploymentsPaginated(params)
+	if err != nil {
+		return loadbalancerservice.Deployment{}, err
+	}
+
+	if len(deployments.Items()) < 1 {
+		return loadbalancerservice.Deployment{}, nil
+	}
+
+	return deployments.Items()[0], nil
+}
+
+// getLatestClusterDeployments returns the most recent deployment for each of the given clusters,
+// retrieved with a single listing of their deployments. Clusters which have never been deployed
+// are omitted
+func getLatestClusterDeployments(service loadbalancerservice.LoadBalancerService, clusterIDs []int) (map[int]loadbalancerservice.Deployment, error) {
+	latest := make(map[int]loadbalancerservice.Deployment)
+	if len(clusterIDs) < 1 {
+		return latest, nil
+	}
+
+	var values []string
+	for _, clusterID := range clusterIDs {
+		values = append(values, strconv.Itoa(clusterID))
+	}
+
+	params := connection.APIRequestParameters{}
+	params.WithFilter(*connection.NewAPIRequestFiltering("cluster_id", connection.INOperator, values))
+
+	deployments, err := service.GetDeployments(params)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deployment := range deployments {
+		if deployment.ID > latest[deployment.ClusterID].ID {
+			latest[deployment.ClusterID] = deployment
+		}
+	}
+
+	return latest, nil
+}
@@ -0,0 +1,107 @@
+package loadbalancer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+func TestGetLatestClusterDeployments(t *testing.T) {
+	conn := &testConnection{
+		statusCode: 200,
+		body: `{"data":[
+			{"id":1,"cluster_id":1,"successful":true},
+			{"id":3,"cluster_id":1,"successful":false},
+			{"id":2,"cluster_id":2,"successful":true}
+		],"meta":{"pagination":{"total":3,"count":3,"per_page":100,"total_pages":1}}}`,
+	}
+
+	deployments, err := getLatestClusterDeployments(loadbalancer.NewService(conn), []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[int]loadbalancer.Deployment{
+		1: {ID: 3, ClusterID: 1},
+		2: {ID: 2, ClusterID: 2, Successful: true},
+	}
+	if !reflect.DeepEqual(deployments, expected) {
+		t.Errorf("expected deployments %v, got %v", expected, deployments)
+	}
+
+	if len(conn.requests) != 1 {
+		t.Errorf("expected deployments to be listed once, got requests %v", conn.requests)
+	}
+}
+
+func TestClusterDeploymentStateRefreshFunc(t *testing.T) {
+	tests := []struct {
+		name        string
+		deployments []loadbalancer.Deployment
+		state       string
+		err         string
+	}{
+		{
+			name:        "pending without deployment",
+			deployments: nil,
+			state:       deploymentStatePending,
+		},
+		{
+			name:        "pending until deployment after previous",
+			deployments: []loadbalancer.Deployment{{ID: 3, ClusterID: 1, Successful: true}, {ID: 4, ClusterID: 2, Successful: true}},
+			state:       deploymentStatePending,
+		},
+		{
+			name:        "successful",
+			deployments: []loadbalancer.Deployment{{ID: 3, ClusterID: 1, Successful: true}, {ID: 4, ClusterID: 1, Successful: true}},
+			state:       deploymentStateSuccessful,
+		},
+		{
+			name:        "failed",
+			deployments: []loadbalancer.Deployment{{ID: 3, ClusterID: 1, Successful: true}, {ID: 4, ClusterID: 1, PSSID: 7}},
+			err:         "deployment with ID [4] failed (PSS ID [7])",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newFakeService(t, clusterConfig{})
+			service.deployments = tc.deployments
+
+			_, state, err := clusterDeploymentStateRefreshFunc(service, 1, 3)()
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if state != tc.state {
+				t.Errorf("expected state %s, got %s", tc.state, state)
+			}
+		})
+	}
+}
+
+func TestDeployClusterTimeout(t *testing.T) {
+	service := newFakeService(t, clusterConfig{})
+	service.deployments = []loadbalancer.Deployment{{ID: 3, ClusterID: 1, Successful: true}}
+
+	// No deployment is recorded after the previous one, so the deployment remains pending
+	_, err := deployCluster(context.Background(), service, 1, 10*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "Error waiting for deployment of cluster with ID [1]") {
+		t.Fatalf("expected timeout waiting for deployment, got %v", err)
+	}
+
+	if !reflect.DeepEqual(service.changes, []string{"deploy cluster 1"}) {
+		t.Errorf("expected cluster to be deployed, got %v", service.changes)
+	}
+}
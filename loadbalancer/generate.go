@@ -0,0 +1,522 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// generateReferences maps attributes holding IDs to the resource types they reference, so IDs
+// can be replaced with references to generated resources
+var generateReferences = map[string]string{
+	"cluster_id":              "loadbalancer_cluster",
+	"vip_id":                  "loadbalancer_vip",
+	"listener_id":             "loadbalancer_listener",
+	"target_group_id":         "loadbalancer_targetgroup",
+	"default_target_group_id": "loadbalancer_targetgroup",
+}
+
+var generateLabelRegexp = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Generate writes Terraform configuration and import blocks for an existing cluster, along
+// with its VIPs, target groups, listeners and their children, to dir
+func Generate(ctx context.Context, apiKey string, clusterID int, dir string) error {
+	files, err := generateConfiguration(ctx, getService(apiKey), clusterID)
+	if err != nil {
+		return err
+	}
+
+	// Existing configuration is never overwritten, as it may have been modified by hand
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return fmt.Errorf("file %s already exists", filepath.Join(dir, name))
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(files) {
+		if err := os.WriteFile(filepath.Join(dir, name), files[name], 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generateConfiguration returns the generated configuration files for the cluster, keyed by
+// file name
+func generateConfiguration(ctx context.Context, service clusterConfigService, clusterID int) (map[string][]byte, error) {
+	config, err := getClusterConfig(service, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGenerator(ctx, service)
+
+	clusterLabel := g.uniqueLabel("loadbalancer_cluster", config.Cluster.Name, strconv.Itoa(clusterID))
+	if err := g.resource("cluster.tf", "loadbalancer_cluster", clusterLabel, strconv.Itoa(clusterID), nil); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(config.VIPs, func(i, j int) bool { return config.VIPs[i].ID < config.VIPs[j].ID })
+	for _, vip := range config.VIPs {
+		label := g.uniqueLabel("loadbalancer_vip", fmt.Sprintf("%s_%d", clusterLabel, vip.ID), strconv.Itoa(vip.ID))
+		if err := g.resource("cluster.tf", "loadbalancer_vip", label, strconv.Itoa(vip.ID), nil); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(config.TargetGroups, func(i, j int) bool { return config.TargetGroups[i].ID < config.TargetGroups[j].ID })
+	targetGroupLabels := make([]string, len(config.TargetGroups))
+	for i, targetGroup := range config.TargetGroups {
+		targetGroupLabel := g.uniqueLabel("loadbalancer_targetgroup", targetGroup.Name, strconv.Itoa(targetGroup.ID))
+		targetGroupLabels[i] = targetGroupLabel
+		if err := g.resource("target_groups.tf", "loadbalancer_targetgroup", targetGroupLabel, strconv.Itoa(targetGroup.ID), nil); err != nil {
+			return nil, err
+		}
+
+		sort.Slice(targetGroup.Targets, func(i, j int) bool { return targetGroup.Targets[i].ID < targetGroup.Targets[j].ID })
+		for _, target := range targetGroup.Targets {
+			name := target.Name
+			if name == "" {
+				name = fmt.Sprintf("%s_%s_%d", targetGroupLabel, target.IP, target.Port)
+			}
+
+			label := g.uniqueLabel("loadbalancer_target", name, strconv.Itoa(target.ID))
+			if err := g.resource("target_groups.tf", "loadbalancer_target", label, fmt.Sprintf("%d/%d", targetGroup.ID, target.ID), nil); err != nil {
+				return nil, err
+			}
+		}
+
+	}
+
+	// ACLs are generated once every target group has been, so the target groups they forward to
+	// are referenced rather than given by ID
+	for i, targetGroup := range config.TargetGroups {
+		if err := g.acls("target_groups.tf", targetGroupLabels[i], targetGroup.ACLs); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(config.Listeners, func(i, j int) bool { return config.Listeners[i].ID < config.Listeners[j].ID })
+	for _, listener := range config.Listeners {
+		listenerLabel := g.uniqueLabel("loadbalancer_listener", listener.Name, strconv.Itoa(listener.ID))
+		if err := g.resource("listeners.tf", "loadbalancer_listener", listenerLabel, strconv.Itoa(listener.ID), nil); err != nil {
+			return nil, err
+		}
+
+		sort.Slice(listener.Binds, func(i, j int) bool { return listener.Binds[i].ID < listener.Binds[j].ID })
+		for _, bind := range listener.Binds {
+			label := g.uniqueLabel("loadbalancer_bind", fmt.Sprintf("%s_%d", listenerLabel, bind.Port), strconv.Itoa(bind.ID))
+			if err := g.resource("listeners.tf", "loadbalancer_bind", label, fmt.Sprintf("%d/%d", listener.ID, bind.ID), nil); err != nil {
+				return nil, err
+			}
+		}
+
+		sort.Slice(listener.Certificates, func(i, j int) bool { return listener.Certificates[i].ID < listener.Certificates[j].ID })
+		for _, certificate := range listener.Certificates {
+			if err := g.certificate(listenerLabel, listener.ID, certificate); err != nil {
+				return nil, err
+			}
+		}
+
+		sort.Slice(listener.AccessIPs, func(i, j int) bool { return listener.AccessIPs[i].ID < listener.AccessIPs[j].ID })
+		for _, accessIP := range listener.AccessIPs {
+			label := g.uniqueLabel("loadbalancer_accessip", fmt.Sprintf("%s_%s", listenerLabel, accessIP.IP), strconv.Itoa(accessIP.ID))
+			if err := g.resource("listeners.tf", "loadbalancer_accessip", label, fmt.Sprintf("%d/%d", listener.ID, accessIP.ID), nil); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := g.acls("listeners.tf", listenerLabel, listener.ACLs); err != nil {
+			return nil, err
+		}
+	}
+
+	files := make(map[string][]byte)
+	for name, file := range g.files {
+		files[name] = hclwrite.Format(file.Bytes())
+	}
+
+	return files, nil
+}
+
+// generator builds configuration for existing resources. Resources are read using their own
+// importer and read functions, so generated configuration matches the state produced by
+// importing them
+type generator struct {
+	ctx       context.Context
+	meta      interface{}
+	resources map[string]*schema.Resource
+	files     map[string]*hclwrite.File
+
+	// labels holds the labels in use for each resource type
+	labels map[string]map[string]bool
+
+	// references holds the address of each generated resource, keyed by resource type and ID
+	references map[string]map[string]string
+}
+
+func newGenerator(ctx context.Context, meta interface{}) *generator {
+	return &generator{
+		ctx:        ctx,
+		meta:       meta,
+		resources:  Provider().ResourcesMap,
+		files:      make(map[string]*hclwrite.File),
+		labels:     make(map[string]map[string]bool),
+		references: make(map[string]map[string]string),
+	}
+}
+
+// resource reads the resource with importID, and appends its configuration to file under label
+// along with an import block
+func (g *generator) resource(file string, resourceType string, label string, importID string, overrides map[string]hclwrite.Tokens) error {
+	r := g.resources[resourceType]
+
+	d, err := importResource(g.ctx, r, importID, g.meta)
+	if err != nil {
+		return fmt.Errorf("error reading %s with ID [%s]: %w", resourceType, importID, err)
+	}
+
+	address := resourceType + "." + label
+
+	body := g.file(file).Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+
+	block := body.AppendNewBlock("resource", []string{resourceType, label})
+	values := make(map[string]interface{})
+	for key := range r.Schema {
+		values[key] = d.Get(key)
+	}
+	g.writeBody(block.Body(), r.Schema, values, overrides)
+
+	imports := g.file("imports.tf").Body()
+	if len(imports.Blocks()) > 0 {
+		imports.AppendNewline()
+	}
+
+	importBlock := imports.AppendNewBlock("import", nil)
+	importBlock.Body().SetAttributeTraversal("to", generateTraversal(resourceType, label))
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(importID))
+
+	if g.references[resourceType] == nil {
+		g.references[resourceType] = make(map[string]string)
+	}
+	g.references[resourceType][d.Id()] = address
+
+	return nil
+}
+
+// certificate generates a certificate. The key and certificate contents can't be retrieved from
+// the API, so are replaced with variables
+func (g *generator) certificate(listenerLabel string, listenerID int, certificate loadbalancerservice.Certificate) error {
+	name := certificate.Name
+	if name == "" {
+		name = listenerLabel
+	}
+
+	label := g.uniqueLabel("loadbalancer_certificate", name, strconv.Itoa(certificate.ID))
+
+	variables := g.file("variables.tf").Body()
+
+	overrides := make(map[string]hclwrite.Tokens)
+	for _, key := range []string{"key", "certificate"} {
+		if len(variables.Blocks()) > 0 {
+			variables.AppendNewline()
+		}
+
+		variable := variables.AppendNewBlock("variable", []string{label + "_" + key})
+		variable.Body().SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+		variable.Body().SetAttributeValue("description", cty.StringVal(fmt.Sprintf("Contents of the %s of certificate %s", key, certificate.Name)))
+		if key == "key" {
+			variable.Body().SetAttributeValue("sensitive", cty.True)
+		}
+
+		overrides[key] = hclwrite.TokensForTraversal(generateTraversal("var", label+"_"+key))
+	}
+
+	return g.resource("listeners.tf", "loadbalancer_certificate", label, fmt.Sprintf("%d/%d", listenerID, certificate.ID), overrides)
+}
+
+// acls generates the ACLs of a listener or target group, ordered by ID
+func (g *generator) acls(file string, parentLabel string, acls []aclWithPriority) error {
+	sort.Slice(acls, func(i, j int) bool { return acls[i].ID < acls[j].ID })
+
+	for _, acl := range acls {
+		name := acl.Name
+		if name == "" {
+			name = fmt.Sprintf("%s_acl_%d", parentLabel, acl.ID)
+		}
+
+		label := g.uniqueLabel("loadbalancer_acl", name, strconv.Itoa(acl.ID))
+		if err := g.resource(file, "loadbalancer_acl", label, strconv.Itoa(acl.ID), nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (g *generator) file(name string) *hclwrite.File {
+	if g.files[name] == nil {
+		g.files[name] = hclwrite.NewEmptyFile()
+	}
+
+	return g.files[name]
+}
+
+// uniqueLabel reserves a label for a resource derived from name, suffixed with the resource ID
+// where the label is already in use
+func (g *generator) uniqueLabel(resourceType string, name string, id string) string {
+	if g.labels[resourceType] == nil {
+		g.labels[resourceType] = make(map[string]bool)
+	}
+
+	label := generateLabel(name, strings.TrimPrefix(resourceType, "loadbalancer_")+"_"+id)
+	if g.labels[resourceType][label] {
+		label = label + "_" + id
+	}
+
+	g.labels[resourceType][label] = true
+
+	return label
+}
+
+// writeBody writes values to body as attributes and nested blocks. Computed only attributes,
+// and optional attributes set to their default, are omitted
+func (g *generator) writeBody(body *hclwrite.Body, schemaMap map[string]*schema.Schema, values map[string]interface{}, overrides map[string]hclwrite.Tokens) {
+	var attributes, blocks []string
+	for key, s := range schemaMap {
+		if s.Computed && !s.Optional {
+			continue
+		}
+
+		if _, ok := s.Elem.(*schema.Resource); ok {
+			blocks = append(blocks, key)
+		} else {
+			attributes = append(attributes, key)
+		}
+	}
+
+	// Required attributes are written first, as they typically identify the resource
+	sort.Slice(attributes, func(i, j int) bool {
+		if schemaMap[attributes[i]].Required != schemaMap[attributes[j]].Required {
+			return schemaMap[attributes[i]].Required
+		}
+
+		return attributes[i] < attributes[j]
+	})
+	sort.Strings(blocks)
+
+	for _, key := range attributes {
+		s := schemaMap[key]
+		value := values[key]
+
+		if tokens, ok := overrides[key]; ok {
+			body.SetAttributeRaw(key, tokens)
+			continue
+		}
+
+		if !s.Required && generateIsDefault(s, value) {
+			continue
+		}
+
+		// Arguments of conditions and actions in the generic form are referenced by their name
+		referenceKey := key
+		if name, ok := values["name"].(string); ok && key == "value" {
+			referenceKey = name
+		}
+
+		if address, ok := g.reference(referenceKey, value); ok {
+			body.SetAttributeTraversal(key, generateTraversal(append(strings.Split(address, "."), "id")...))
+			continue
+		}
+
+		if s.Required && generateIsDefault(s, value) {
+			body.AppendUnstructuredTokens(hclwrite.Tokens{{
+				Type:  hclsyntax.TokenComment,
+				Bytes: []byte("# Empty or not returned by the API, set to match the existing resource\n"),
+			}})
+		}
+
+		body.SetAttributeValue(key, generateValue(value))
+	}
+
+	for _, key := range blocks {
+		elem := schemaMap[key].Elem.(*schema.Resource)
+
+		for _, rawElement := range generateList(values[key]) {
+			element, _ := rawElement.(map[string]interface{})
+			if len(element) == 0 {
+				continue
+			}
+
+			if len(body.Attributes())+len(body.Blocks()) > 0 {
+				body.AppendNewline()
+			}
+
+			g.writeBody(body.AppendNewBlock(key, nil).Body(), elem.Schema, element, nil)
+		}
+	}
+}
+
+// reference returns the address of the generated resource referenced by an ID attribute
+func (g *generator) reference(key string, value interface{}) (string, bool) {
+	resourceType, ok := generateReferences[key]
+	if !ok {
+		return "", false
+	}
+
+	var id string
+	switch v := value.(type) {
+	case int:
+		id = strconv.Itoa(v)
+	case string:
+		id = v
+	default:
+		return "", false
+	}
+
+	address, ok := g.references[resourceType][id]
+
+	return address, ok
+}
+
+// importResource imports and reads the resource with the given import ID
+func importResource(ctx context.Context, r *schema.Resource, importID string, meta interface{}) (*schema.ResourceData, error) {
+	d := r.Data(nil)
+	d.SetId(importID)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		imported, err := r.Importer.StateContext(ctx, d, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		d = imported[0]
+	}
+
+	diags := r.ReadContext(ctx, d, meta)
+	if diags.HasError() {
+		var errs []error
+		for _, diagnostic := range diags {
+			errs = append(errs, errors.New(diagnostic.Summary))
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	if d.Id() == "" {
+		return nil, errors.New("resource not found")
+	}
+
+	return d, nil
+}
+
+// generateLabel returns a resource label derived from name, or fallback where name doesn't
+// contain any valid characters
+func generateLabel(name string, fallback string) string {
+	label := strings.Trim(generateLabelRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_-")
+	if label == "" {
+		return fallback
+	}
+
+	if label[0] >= '0' && label[0] <= '9' {
+		return "_" + label
+	}
+
+	return label
+}
+
+func generateTraversal(names ...string) hcl.Traversal {
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: names[0]}}
+	for _, name := range names[1:] {
+		traversal = append(traversal, hcl.TraverseAttr{Name: name})
+	}
+
+	return traversal
+}
+
+// generateIsDefault returns whether value is the default of an attribute, which is its zero value
+// where the schema doesn't specify a default
+func generateIsDefault(s *schema.Schema, value interface{}) bool {
+	if s.Default != nil {
+		return value == s.Default
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	default:
+		return len(generateList(value)) == 0
+	}
+}
+
+func generateList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		return v.List()
+	default:
+		return nil
+	}
+}
+
+// generateValue converts an attribute value to its cty equivalent
+func generateValue(value interface{}) cty.Value {
+	switch v := value.(type) {
+	case string:
+		return cty.StringVal(v)
+	case int:
+		return cty.NumberIntVal(int64(v))
+	case float64:
+		return cty.NumberFloatVal(v)
+	case bool:
+		return cty.BoolVal(v)
+	case map[string]interface{}:
+		values := make(map[string]cty.Value)
+		for key, element := range v {
+			values[key] = generateValue(element)
+		}
+
+		return cty.ObjectVal(values)
+	default:
+		var values []cty.Value
+		for _, element := range generateList(value) {
+			values = append(values, generateValue(element))
+		}
+
+		if len(values) == 0 {
+			return cty.EmptyTupleVal
+		}
+
+		return cty.TupleVal(values)
+	}
+}
@@ -0,0 +1,259 @@
+package loadbalancer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestGeneratorWriteBody(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		raw          map[string]interface{}
+		expected     string
+	}{
+		{
+			name:         "references and typed blocks",
+			resourceType: "loadbalancer_acl",
+			raw: map[string]interface{}{
+				"listener_id": 10,
+				"name":        "static",
+				"priority":    2,
+				"condition": []interface{}{
+					map[string]interface{}{
+						"path_begins_with": []interface{}{map[string]interface{}{"path": "/static"}},
+					},
+				},
+				"action": []interface{}{
+					map[string]interface{}{
+						"use_target_group": []interface{}{map[string]interface{}{"target_group_id": 21}},
+					},
+				},
+			},
+			expected: `listener_id = loadbalancer_listener.web.id
+name        = "static"
+priority    = 2
+
+action {
+  use_target_group {
+    target_group_id = loadbalancer_targetgroup.static.id
+  }
+}
+
+condition {
+  path_begins_with {
+    path = "/static"
+  }
+}
+`,
+		},
+		{
+			name:         "references in generic arguments",
+			resourceType: "loadbalancer_acl",
+			raw: map[string]interface{}{
+				"target_group_id": 21,
+				"condition": []interface{}{
+					map[string]interface{}{
+						"name":     "header_matches",
+						"argument": []interface{}{map[string]interface{}{"name": "value", "value": "21"}},
+					},
+				},
+				"action": []interface{}{
+					map[string]interface{}{
+						"name":     "use_target_group",
+						"argument": []interface{}{map[string]interface{}{"name": "target_group_id", "value": "21"}},
+					},
+				},
+			},
+			expected: `target_group_id = loadbalancer_targetgroup.static.id
+
+action {
+  name = "use_target_group"
+
+  argument {
+    name  = "target_group_id"
+    value = loadbalancer_targetgroup.static.id
+  }
+}
+
+condition {
+  name = "header_matches"
+
+  argument {
+    name  = "value"
+    value = "21"
+  }
+}
+`,
+		},
+		{
+			name:         "unknown reference and required value not returned by the API",
+			resourceType: "loadbalancer_vip",
+			raw: map[string]interface{}{
+				"cluster_id":    2,
+				"type":          "",
+				"cidr":          "10.0.0.5/24",
+				"internal_cidr": "10.0.0.5/24",
+			},
+			expected: `cidr       = "10.0.0.5/24"
+cluster_id = 2
+# Empty or not returned by the API, set to match the existing resource
+type = ""
+`,
+		},
+		{
+			name:         "optional values differing from defaults",
+			resourceType: "loadbalancer_cluster",
+			raw: map[string]interface{}{
+				"name":                "cluster-1",
+				"deletion_protection": false,
+			},
+			expected: `name                = "cluster-1"
+deletion_protection = false
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGenerator(context.Background(), nil)
+			g.references = map[string]map[string]string{
+				"loadbalancer_listener":    {"10": "loadbalancer_listener.web"},
+				"loadbalancer_targetgroup": {"21": "loadbalancer_targetgroup.static"},
+			}
+
+			r := g.resources[tt.resourceType]
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.raw)
+
+			values := make(map[string]interface{})
+			for key := range r.Schema {
+				values[key] = d.Get(key)
+			}
+
+			file := hclwrite.NewEmptyFile()
+			g.writeBody(file.Body(), r.Schema, values, nil)
+
+			if actual := string(hclwrite.Format(file.Bytes())); actual != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGeneratorResourceVIP(t *testing.T) {
+	conn := &testConnection{
+		statusCode: 200,
+		body:       `{"data":{"id":5,"cluster_id":2,"internal_cidr":"10.0.0.5/24","external_cidr":""}}`,
+	}
+
+	g := newGenerator(context.Background(), newProviderService(conn, loadbalancer.NewService(conn)))
+	g.references = map[string]map[string]string{
+		"loadbalancer_cluster": {"2": "loadbalancer_cluster.cluster-1"},
+	}
+
+	if err := g.resource("cluster.tf", "loadbalancer_vip", "vip_5", "5", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := `resource "loadbalancer_vip" "vip_5" {
+  cidr       = "10.0.0.5/24"
+  cluster_id = loadbalancer_cluster.cluster-1.id
+  type       = "internal"
+}
+`
+	if actual := string(hclwrite.Format(g.files["cluster.tf"].Bytes())); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+
+	if _, diags := hclsyntax.ParseConfig(g.files["cluster.tf"].Bytes(), "cluster.tf", hcl.InitialPos); diags.HasErrors() {
+		t.Errorf("unexpected error parsing generated configuration: %s", diags)
+	}
+
+	if imports := string(g.files["imports.tf"].Bytes()); !strings.Contains(imports, "loadbalancer_vip.vip_5") {
+		t.Errorf("expected import block for VIP, got:\n%s", imports)
+	}
+}
+
+func TestGenerateConfigurationACLReferences(t *testing.T) {
+	useTargetGroup := func(targetGroupID int) []loadbalancer.ACLAction {
+		return []loadbalancer.ACLAction{{
+			Name:      "use_target_group",
+			Arguments: map[string]loadbalancer.ACLArgument{"target_group_id": {Name: "target_group_id", Value: targetGroupID}},
+		}}
+	}
+
+	// The ACL of the first target group forwards to the second, which is generated after it
+	service := newFakeService(t, clusterConfig{
+		Cluster: loadbalancer.Cluster{ID: 1, Name: "cluster-1"},
+		TargetGroups: []targetGroupConfig{
+			{
+				TargetGroup: loadbalancer.TargetGroup{ID: 20, ClusterID: 1, Name: "web"},
+				ACLs: []aclWithPriority{
+					{ACL: loadbalancer.ACL{ID: 30, TargetGroupID: 20, Name: "static", Actions: useTargetGroup(21)}, Priority: 1},
+				},
+			},
+			{TargetGroup: loadbalancer.TargetGroup{ID: 21, ClusterID: 1, Name: "static"}},
+		},
+		Listeners: []listenerConfig{
+			{
+				Listener: loadbalancer.Listener{ID: 10, ClusterID: 1, Name: "web", DefaultTargetGroupID: 20},
+				ACLs: []aclWithPriority{
+					{ACL: loadbalancer.ACL{ID: 31, ListenerID: 10, Name: "api", Actions: useTargetGroup(21)}, Priority: 1},
+				},
+			},
+		},
+	})
+
+	files, err := generateConfiguration(context.Background(), service, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, address := range map[string]string{"target_groups.tf": "loadbalancer_acl.static", "listeners.tf": "loadbalancer_acl.api"} {
+		file, diags := hclsyntax.ParseConfig(files[name], name, hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected error parsing %s: %s", name, diags)
+		}
+
+		var found bool
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "resource" || block.Labels[0]+"."+block.Labels[1] != address {
+				continue
+			}
+			found = true
+
+			reference := block.Body.Blocks[0].Body.Blocks[0].Body.Attributes["target_group_id"].Expr.Variables()
+			if len(reference) != 1 || string(hclwrite.TokensForTraversal(reference[0]).Bytes()) != "loadbalancer_targetgroup.static.id" {
+				t.Errorf("expected %s to reference target group static, got:\n%s", address, files[name])
+			}
+		}
+
+		if !found {
+			t.Errorf("expected %s within %s, got:\n%s", address, name, files[name])
+		}
+	}
+}
+
+func TestGenerateLabel(t *testing.T) {
+	tests := map[string]string{
+		"web":           "web",
+		"Web Servers":   "web_servers",
+		"api.example-1": "api_example-1",
+		"10.0.0.1":      "_10_0_0_1",
+		" !! ":          "fallback",
+		"_internal_":    "internal",
+	}
+
+	for name, expected := range tests {
+		if actual := generateLabel(name, "fallback"); actual != expected {
+			t.Errorf("generateLabel(%q): expected %q, got %q", name, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,476 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// haproxyWriter builds the lines of an HAProxy configuration file
+type haproxyWriter struct {
+	strings.Builder
+}
+
+func (w *haproxyWriter) section(format string, a ...any) {
+	if w.Len() > 0 {
+		w.WriteString("\n")
+	}
+	fmt.Fprintf(w, format+"\n", a...)
+}
+
+func (w *haproxyWriter) line(format string, a ...any) {
+	fmt.Fprintf(w, "    "+format+"\n", a...)
+}
+
+// renderHAProxyConfig renders an approximation of the HAProxy configuration for config. The
+// output only depends on config, with objects ordered by ID and ACLs by priority, so it's
+// stable for review
+func renderHAProxyConfig(config clusterConfig) string {
+	w := &haproxyWriter{}
+
+	fmt.Fprintf(w, "# Approximate HAProxy configuration for cluster %d (%s)\n", config.Cluster.ID, config.Cluster.Name)
+	w.WriteString("# This is rendered from the staged configuration for review, and is not the deployed configuration\n")
+
+	vips := make(map[int]loadbalancer.VIP)
+	for _, vip := range config.VIPs {
+		vips[vip.ID] = vip
+	}
+
+	listeners := append([]listenerConfig(nil), config.Listeners...)
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].ID < listeners[j].ID })
+
+	for _, listener := range listeners {
+		renderHAProxyListener(w, listener, vips)
+	}
+
+	targetGroups := append([]targetGroupConfig(nil), config.TargetGroups...)
+	sort.Slice(targetGroups, func(i, j int) bool { return targetGroups[i].ID < targetGroups[j].ID })
+
+	for _, targetGroup := range targetGroups {
+		renderHAProxyTargetGroup(w, targetGroup)
+	}
+
+	return w.String()
+}
+
+func renderHAProxyListener(w *haproxyWriter, listener listenerConfig, vips map[int]loadbalancer.VIP) {
+	w.section("frontend listener_%d", listener.ID)
+	if listener.Name != "" {
+		w.line("description %s", listener.Name)
+	}
+	w.line("mode %s", listener.Mode)
+
+	binds := append([]loadbalancer.Bind(nil), listener.Binds...)
+	sort.Slice(binds, func(i, j int) bool { return binds[i].ID < binds[j].ID })
+
+	certificates := append([]loadbalancer.Certificate(nil), listener.Certificates...)
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].ID < certificates[j].ID })
+
+	for _, bind := range binds {
+		address := fmt.Sprintf("vip_%d", bind.VIPID)
+		if vip, ok := vips[bind.VIPID]; ok {
+			address, _, _ = strings.Cut(vip.InternalCIDR, "/")
+		}
+
+		// Binds don't report whether they terminate TLS, so binds on the plain HTTP port are
+		// assumed not to where the listener has certificates
+		options := ""
+		if len(certificates) > 0 && bind.Port != 80 {
+			options = fmt.Sprintf(" ssl crt /etc/haproxy/certs/listener_%d/%s", listener.ID, haproxyBindSSLOptions(listener.Listener))
+		}
+
+		w.line("bind %s:%d%s", address, bind.Port, options)
+	}
+
+	for _, certificate := range certificates {
+		w.line("# certificate %d %s expires %s", certificate.ID, certificate.Name, certificate.ExpiresAt)
+	}
+
+	if listener.TimeoutsClient > 0 {
+		w.line("timeout client %d", listener.TimeoutsClient)
+	}
+
+	if listener.Close {
+		w.line("option httpclose")
+	}
+
+	if listener.RedirectHTTPS {
+		w.line("http-request redirect scheme https unless { ssl_fc }")
+	}
+
+	if listener.HSTSEnabled {
+		w.line("http-response set-header Strict-Transport-Security max-age=%d", listener.HSTSMaxAge)
+	}
+
+	if listener.GeoIP != nil {
+		var locations []string
+		locations = append(locations, listener.GeoIP.Continents...)
+		locations = append(locations, listener.GeoIP.Countries...)
+		if listener.GeoIP.EuropeanUnion {
+			locations = append(locations, "EU")
+		}
+		w.line("# geoip %s %s", listener.GeoIP.Restriction, strings.Join(locations, " "))
+	}
+
+	if len(listener.AccessIPs) > 0 {
+		accessIPs := append([]loadbalancer.AccessIP(nil), listener.AccessIPs...)
+		sort.Slice(accessIPs, func(i, j int) bool { return accessIPs[i].ID < accessIPs[j].ID })
+
+		var ips []string
+		for _, accessIP := range accessIPs {
+			ips = append(ips, accessIP.IP.String())
+		}
+
+		w.line("acl access_ip src %s", strings.Join(ips, " "))
+		if listener.AccessIsAllowList {
+			w.line("tcp-request connection reject unless access_ip")
+		} else {
+			w.line("tcp-request connection reject if access_ip")
+		}
+	}
+
+	renderHAProxyACLs(w, listener.ACLs)
+	renderHAProxyCustomOptions(w, listener.CustomOptions)
+
+	if listener.DefaultTargetGroupID > 0 {
+		w.line("default_backend %s", haproxyBackendName(listener.DefaultTargetGroupID))
+	}
+}
+
+func haproxyBindSSLOptions(listener loadbalancer.Listener) string {
+	var options []string
+
+	switch {
+	case listener.AllowTLSV1:
+		options = append(options, "ssl-min-ver TLSv1.0")
+	case listener.AllowTLSV11:
+		options = append(options, "ssl-min-ver TLSv1.1")
+	case listener.DisableTLSV12:
+		options = append(options, "ssl-min-ver TLSv1.3")
+	default:
+		options = append(options, "ssl-min-ver TLSv1.2")
+	}
+
+	switch {
+	case listener.HTTP2Only:
+		options = append(options, "alpn h2")
+	case !listener.DisableHTTP2:
+		options = append(options, "alpn h2,http/1.1")
+	}
+
+	if len(listener.CustomCiphers) > 0 {
+		options = append(options, "ciphers "+listener.CustomCiphers)
+	}
+
+	return " " + strings.Join(options, " ")
+}
+
+func renderHAProxyTargetGroup(w *haproxyWriter, targetGroup targetGroupConfig) {
+	w.section("backend %s", haproxyBackendName(targetGroup.ID))
+	if targetGroup.Name != "" {
+		w.line("description %s", targetGroup.Name)
+	}
+	w.line("mode %s", targetGroup.Mode)
+
+	if targetGroup.Balance != "" {
+		w.line("balance %s", targetGroup.Balance)
+	}
+
+	if targetGroup.Close {
+		w.line("option httpclose")
+	}
+
+	if targetGroup.Sticky {
+		w.line("cookie SERVERID insert indirect nocache%s", haproxyOptional(targetGroup.CookieOpts))
+	}
+
+	if targetGroup.Source != "" {
+		w.line("source %s", targetGroup.Source)
+	}
+
+	for _, timeout := range []struct {
+		name  string
+		value int
+	}{
+		{"connect", targetGroup.TimeoutsConnect},
+		{"server", targetGroup.TimeoutsServer},
+		{"http-request", targetGroup.TimeoutsHTTPRequest},
+		{"check", targetGroup.TimeoutsCheck},
+		{"tunnel", targetGroup.TimeoutsTunnel},
+	} {
+		if timeout.value > 0 {
+			w.line("timeout %s %d", timeout.name, timeout.value)
+		}
+	}
+
+	switch {
+	case targetGroup.MonitorTCPMonitoring:
+		w.line("option tcp-check")
+	case targetGroup.MonitorURL != "":
+		w.line("option httpchk")
+		w.line("http-check send meth %s uri %s%s%s", haproxyDefault(targetGroup.MonitorMethod.String(), "GET"), targetGroup.MonitorURL,
+			haproxyOptional("ver "+targetGroup.MonitorHTTPVersion, targetGroup.MonitorHTTPVersion), haproxyOptional("hdr host "+targetGroup.MonitorHost, targetGroup.MonitorHost))
+
+		if targetGroup.MonitorExpectString != "" {
+			match := "string"
+			if targetGroup.MonitorExpectStringRegex {
+				match = "rstring"
+			}
+			w.line("http-check expect %s %s", match, targetGroup.MonitorExpectString)
+		} else if targetGroup.MonitorExpect != "" {
+			w.line("http-check expect status %s", targetGroup.MonitorExpect)
+		}
+	}
+
+	renderHAProxyACLs(w, targetGroup.ACLs)
+	renderHAProxyCustomOptions(w, targetGroup.CustomOptions)
+
+	targets := append([]loadbalancer.Target(nil), targetGroup.Targets...)
+	sort.Slice(targets, func(i, j int) bool { return targets[i].ID < targets[j].ID })
+
+	for _, target := range targets {
+		w.line("server target_%d %s:%d%s", target.ID, target.IP, target.Port, haproxyServerOptions(targetGroup.TargetGroup, target))
+	}
+}
+
+func haproxyServerOptions(targetGroup loadbalancer.TargetGroup, target loadbalancer.Target) string {
+	var options []string
+
+	if target.Weight > 0 {
+		options = append(options, fmt.Sprintf("weight %d", target.Weight))
+	}
+
+	options = append(options, "check")
+	if target.CheckInterval > 0 {
+		options = append(options, fmt.Sprintf("inter %d", target.CheckInterval))
+	}
+	if target.CheckRise > 0 {
+		options = append(options, fmt.Sprintf("rise %d", target.CheckRise))
+	}
+	if target.CheckFall > 0 {
+		options = append(options, fmt.Sprintf("fall %d", target.CheckFall))
+	}
+	if targetGroup.CheckPort > 0 {
+		options = append(options, fmt.Sprintf("port %d", targetGroup.CheckPort))
+	}
+	if target.CheckSSL {
+		options = append(options, "check-ssl")
+	}
+
+	if targetGroup.SSL {
+		options = append(options, "ssl")
+		if targetGroup.SSLVerify {
+			options = append(options, "verify required")
+		} else {
+			options = append(options, "verify none")
+		}
+		if targetGroup.SNI {
+			options = append(options, "sni req.hdr(host)")
+		}
+	}
+
+	switch {
+	case target.HTTP2Only:
+		options = append(options, "proto h2")
+	case targetGroup.SSL && !target.DisableHTTP2:
+		options = append(options, "alpn h2,http/1.1")
+	}
+
+	switch {
+	case targetGroup.SendProxyV2:
+		options = append(options, "send-proxy-v2")
+	case targetGroup.SendProxy:
+		options = append(options, "send-proxy")
+	}
+
+	if targetGroup.Sticky && target.SessionCookieValue != "" {
+		options = append(options, "cookie "+target.SessionCookieValue)
+	}
+
+	if target.Backup {
+		options = append(options, "backup")
+	}
+
+	if !target.Active {
+		options = append(options, "disabled")
+	}
+
+	if target.Name != "" {
+		options = append(options, "# "+target.Name)
+	}
+
+	return " " + strings.Join(options, " ")
+}
+
+// renderHAProxyACLs renders each ACL in priority order as rules for its actions, conditional on its conditions
+func renderHAProxyACLs(w *haproxyWriter, acls []aclWithPriority) {
+	sorted := append([]aclWithPriority(nil), acls...)
+	sortACLsByPriority(sorted)
+
+	for _, acl := range sorted {
+		w.line("# acl %d %s", acl.ID, acl.Name)
+
+		supported := true
+
+		var conditions []string
+		for _, condition := range acl.Conditions {
+			expression, ok := haproxyCondition(condition)
+			conditions = append(conditions, expression)
+			supported = supported && ok
+		}
+
+		condition := ""
+		if len(conditions) > 0 {
+			condition = " if " + strings.Join(conditions, " ")
+		}
+
+		// Rules without an HAProxy equivalent are rendered as comments
+		for _, action := range acl.Actions {
+			rule, ok := haproxyAction(action)
+			if !supported || !ok {
+				rule = "# " + rule
+			}
+
+			w.line("%s%s", rule, condition)
+		}
+	}
+}
+
+func haproxyCondition(condition loadbalancer.ACLCondition) (string, bool) {
+	arguments := haproxyArguments(condition.Arguments)
+
+	supported := true
+
+	var expression string
+	switch {
+	case condition.Name == "header_matches" && hasArguments(arguments, "header", "value"):
+		expression = fmt.Sprintf("hdr(%s) %s", arguments["header"], haproxyQuote(arguments["value"]))
+	case condition.Name == "path_begins_with" && hasArguments(arguments, "path"):
+		expression = "path_beg " + haproxyQuote(arguments["path"])
+	case condition.Name == "source_ip" && hasArguments(arguments, "ip"):
+		expression = "src " + strings.Join(haproxyList(arguments["ip"]), " ")
+	default:
+		expression = haproxyGeneric(condition.Name, arguments)
+		supported = false
+	}
+
+	if condition.Inverted {
+		return "!{ " + expression + " }", supported
+	}
+
+	return "{ " + expression + " }", supported
+}
+
+func haproxyAction(action loadbalancer.ACLAction) (string, bool) {
+	arguments := haproxyArguments(action.Arguments)
+
+	switch {
+	case action.Name == "redirect" && hasArguments(arguments, "location"):
+		if status, ok := arguments["status"]; ok {
+			return fmt.Sprintf("http-request redirect location %s code %s", haproxyQuote(arguments["location"]), status), true
+		}
+		return "http-request redirect location " + haproxyQuote(arguments["location"]), true
+	case action.Name == "use_target_group" && hasArguments(arguments, "target_group_id"):
+		targetGroupID, err := strconv.Atoi(arguments["target_group_id"])
+		if err == nil {
+			return "use_backend " + haproxyBackendName(targetGroupID), true
+		}
+	case action.Name == "set_header" && hasArguments(arguments, "header", "value"):
+		return fmt.Sprintf("http-request set-header %s %s", arguments["header"], haproxyQuote(arguments["value"])), true
+	}
+
+	return haproxyGeneric(action.Name, arguments), false
+}
+
+// haproxyArguments returns the string representation of each argument, keyed by name
+func haproxyArguments(arguments map[string]loadbalancer.ACLArgument) map[string]string {
+	values := make(map[string]string)
+	for name, argument := range arguments {
+		if argument.Name != "" {
+			name = argument.Name
+		}
+		values[name] = flattenACLArgumentValue(argument.Value)
+	}
+
+	return values
+}
+
+func hasArguments(arguments map[string]string, names ...string) bool {
+	for _, name := range names {
+		if _, ok := arguments[name]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// haproxyGeneric renders a condition or action without an HAProxy equivalent
+func haproxyGeneric(name string, arguments map[string]string) string {
+	expression := name
+	for _, key := range sortedKeys(arguments) {
+		expression += fmt.Sprintf(" %s=%s", key, haproxyQuote(arguments[key]))
+	}
+
+	return expression
+}
+
+// haproxyList splits a list argument, which is JSON encoded by flattenACLArgumentValue
+func haproxyList(value string) []string {
+	values, err := flattenACLBlockFieldValue(aclBlockField{valueType: schema.TypeList}, value)
+	if err != nil {
+		return []string{value}
+	}
+
+	var list []string
+	for _, v := range values.([]interface{}) {
+		list = append(list, v.(string))
+	}
+
+	return list
+}
+
+func haproxyQuote(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\"'\\#") {
+		return value
+	}
+
+	return strconv.Quote(value)
+}
+
+func haproxyOptional(value string, condition ...string) string {
+	check := value
+	if len(condition) > 0 {
+		check = condition[0]
+	}
+
+	if check == "" {
+		return ""
+	}
+
+	return " " + value
+}
+
+func haproxyDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+
+	return value
+}
+
+func renderHAProxyCustomOptions(w *haproxyWriter, customOptions string) {
+	for _, option := range strings.Split(customOptions, "\n") {
+		if option = strings.TrimSpace(option); option != "" {
+			w.line("%s", option)
+		}
+	}
+}
+
+func haproxyBackendName(targetGroupID int) string {
+	return fmt.Sprintf("target_group_%d", targetGroupID)
+}
@@ -0,0 +1,184 @@
+package loadbalancer
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func testClusterConfig() clusterConfig {
+	return clusterConfig{
+		Cluster: loadbalancer.Cluster{ID: 1, Name: "cluster-1"},
+		VIPs: []loadbalancer.VIP{
+			{ID: 2, ClusterID: 1, InternalCIDR: "10.0.0.6/24"},
+			{ID: 1, ClusterID: 1, InternalCIDR: "10.0.0.5/24"},
+		},
+		// Listed out of order, to ensure output is ordered by ID
+		TargetGroups: []targetGroupConfig{
+			{
+				TargetGroup: loadbalancer.TargetGroup{
+					ID:                   21,
+					Name:                 "static",
+					Mode:                 loadbalancer.ModeHTTP,
+					Balance:              loadbalancer.TargetGroupBalanceLeastConn,
+					MonitorTCPMonitoring: true,
+				},
+				Targets: []loadbalancer.Target{
+					{ID: 102, TargetGroupID: 21, IP: "10.0.1.12", Port: 8080, Active: false},
+				},
+			},
+			{
+				TargetGroup: loadbalancer.TargetGroup{
+					ID:                 20,
+					Name:               "web",
+					Mode:               loadbalancer.ModeHTTP,
+					Balance:            loadbalancer.TargetGroupBalanceRoundRobin,
+					Sticky:             true,
+					TimeoutsConnect:    5000,
+					TimeoutsServer:     50000,
+					MonitorURL:         "/health",
+					MonitorMethod:      loadbalancer.TargetGroupMonitorMethodGET,
+					MonitorHost:        "example.com",
+					MonitorHTTPVersion: "HTTP/1.1",
+					MonitorExpect:      "200",
+					SSL:                true,
+					SNI:                true,
+					CustomOptions:      "option forwardfor\n\nretries 3\n",
+				},
+				Targets: []loadbalancer.Target{
+					{ID: 101, TargetGroupID: 20, Name: "web-2", IP: "10.0.1.11", Port: 443, Weight: 50, Backup: true, Active: true, SessionCookieValue: "web2"},
+					{ID: 100, TargetGroupID: 20, Name: "web-1", IP: "10.0.1.10", Port: 443, Weight: 100, CheckInterval: 2000, CheckRise: 2, CheckFall: 3, CheckSSL: true, Active: true, SessionCookieValue: "web1"},
+				},
+				ACLs: []aclWithPriority{
+					{
+						ACL: loadbalancer.ACL{
+							ID:   31,
+							Name: "forwarded-proto",
+							Conditions: []loadbalancer.ACLCondition{
+								{Name: "source_ip", Arguments: map[string]loadbalancer.ACLArgument{"ip": {Name: "ip", Value: []interface{}{"10.0.0.0/8", "192.168.0.0/16"}}}},
+							},
+							Actions: []loadbalancer.ACLAction{
+								{Name: "set_header", Arguments: map[string]loadbalancer.ACLArgument{"header": {Name: "header", Value: "X-Forwarded-Proto"}, "value": {Name: "value", Value: "https"}}},
+							},
+						},
+						Priority: 1,
+					},
+				},
+			},
+		},
+		Listeners: []listenerConfig{
+			{
+				Listener: loadbalancer.Listener{
+					ID:                   10,
+					Name:                 "web",
+					ClusterID:            1,
+					Mode:                 loadbalancer.ModeHTTP,
+					RedirectHTTPS:        true,
+					HSTSEnabled:          true,
+					HSTSMaxAge:           31536000,
+					DefaultTargetGroupID: 20,
+					AccessIsAllowList:    false,
+					TimeoutsClient:       30000,
+				},
+				Binds: []loadbalancer.Bind{
+					{ID: 3, ListenerID: 10, VIPID: 1, Port: 443},
+					{ID: 2, ListenerID: 10, VIPID: 1, Port: 80},
+					{ID: 4, ListenerID: 10, VIPID: 9, Port: 8443},
+				},
+				Certificates: []loadbalancer.Certificate{
+					{ID: 40, ListenerID: 10, Name: "example.com", ExpiresAt: "2030-01-01T00:00:00+00:00"},
+				},
+				AccessIPs: []loadbalancer.AccessIP{
+					{ID: 51, IP: "203.0.113.2"},
+					{ID: 50, IP: "203.0.113.1"},
+				},
+				ACLs: []aclWithPriority{
+					{
+						ACL: loadbalancer.ACL{
+							ID:   31,
+							Name: "static",
+							Conditions: []loadbalancer.ACLCondition{
+								{Name: "path_begins_with", Arguments: map[string]loadbalancer.ACLArgument{"path": {Name: "path", Value: "/static"}}},
+								{Name: "header_matches", Inverted: true, Arguments: map[string]loadbalancer.ACLArgument{"header": {Name: "header", Value: "host"}, "value": {Name: "value", Value: "admin.example.com"}}},
+							},
+							Actions: []loadbalancer.ACLAction{
+								{Name: "use_target_group", Arguments: map[string]loadbalancer.ACLArgument{"target_group_id": {Name: "target_group_id", Value: float64(21)}}},
+							},
+						},
+						Priority: 1,
+					},
+					{
+						ACL: loadbalancer.ACL{
+							ID:   30,
+							Name: "legacy",
+							Conditions: []loadbalancer.ACLCondition{
+								{Name: "header_matches", Arguments: map[string]loadbalancer.ACLArgument{"header": {Value: "host"}, "value": {Value: "old.example.com"}}},
+							},
+							Actions: []loadbalancer.ACLAction{
+								{Name: "redirect", Arguments: map[string]loadbalancer.ACLArgument{"location": {Name: "location", Value: "https://example.com"}, "status": {Name: "status", Value: float64(301)}}},
+								{Name: "custom_action", Arguments: map[string]loadbalancer.ACLArgument{"b": {Name: "b", Value: "two words"}, "a": {Name: "a", Value: true}}},
+							},
+						},
+						Priority: 2,
+					},
+				},
+			},
+			{
+				Listener: loadbalancer.Listener{
+					ID:                11,
+					ClusterID:         1,
+					Mode:              loadbalancer.ModeTCP,
+					AccessIsAllowList: true,
+					GeoIP: &loadbalancer.ListenerGeoIP{
+						Restriction: loadbalancer.ListenerGeoIPRestrictionAllow,
+						Countries:   []string{"GB", "IE"},
+					},
+					CustomOptions: "tcp-request inspect-delay 5s",
+				},
+				Binds: []loadbalancer.Bind{
+					{ID: 5, ListenerID: 11, VIPID: 2, Port: 3306},
+				},
+				AccessIPs: []loadbalancer.AccessIP{
+					{ID: 52, IP: "198.51.100.0/24"},
+				},
+			},
+		},
+	}
+}
+
+func TestRenderHAProxyConfig(t *testing.T) {
+	golden := filepath.Join("testdata", "haproxy_config.golden")
+
+	rendered := renderHAProxyConfig(testClusterConfig())
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(golden), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(golden, []byte(rendered), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("error reading golden file, run with -update to create it: %s", err)
+	}
+
+	if rendered != string(expected) {
+		t.Errorf("rendered configuration doesn't match %s, run with -update if the change is expected\n\ngot:\n%s", golden, rendered)
+	}
+
+	// Rendering must be deterministic, as the output is intended to be diffed
+	for i := 0; i < 10; i++ {
+		if again := renderHAProxyConfig(testClusterConfig()); again != rendered {
+			t.Fatalf("rendered configuration isn't deterministic:\n%s", again)
+		}
+	}
+}
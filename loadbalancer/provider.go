@@ -1,14 +1,18 @@
 package loadbalancer
 
 import (
+	"context"
 	"errors"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ans-group/sdk-go/pkg/client"
 	"github.com/ans-group/sdk-go/pkg/connection"
-	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func Provider() *schema.Provider {
@@ -28,42 +32,104 @@ func Provider() *schema.Provider {
 				},
 				Description: "API token required to authenticate with ANS APIs. See https://developers.ukfast.io for more details",
 			},
+			"auto_deploy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"settle_delay": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of seconds without further changes to a cluster to wait before deploying it",
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      900,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Number of seconds to wait for a deployment to complete",
+						},
+						"warn_on_failure": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Report failed deployments as warnings rather than errors, so that the changes which triggered them aren't tainted",
+						},
+					},
+				},
+				Description: "Automatically deploy each cluster changed by this provider once changes to it have completed",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"loadbalancer_accessip":    dataSourceAccessIP(),
-			"loadbalancer_acl":         dataSourceACL(),
-			"loadbalancer_bind":        dataSourceBind(),
-			"loadbalancer_certificate": dataSourceCertificate(),
-			"loadbalancer_cluster":     dataSourceCluster(),
-			"loadbalancer_listener":    dataSourceListener(),
-			"loadbalancer_target":      dataSourceTarget(),
-			"loadbalancer_targetgroup": dataSourceTargetGroup(),
-			"loadbalancer_vip":         dataSourceVip(),
+			"loadbalancer_accessip":           dataSourceAccessIP(),
+			"loadbalancer_accessips":          dataSourceAccessIPs(),
+			"loadbalancer_acl":                dataSourceACL(),
+			"loadbalancer_acl_templates":      dataSourceACLTemplates(),
+			"loadbalancer_acls":               dataSourceACLs(),
+			"loadbalancer_bind":               dataSourceBind(),
+			"loadbalancer_binds":              dataSourceBinds(),
+			"loadbalancer_certificate":        dataSourceCertificate(),
+			"loadbalancer_certificates":       dataSourceCertificates(),
+			"loadbalancer_cluster":            dataSourceCluster(),
+			"loadbalancer_cluster_snapshot":   dataSourceClusterSnapshot(),
+			"loadbalancer_cluster_validation": dataSourceClusterValidation(),
+			"loadbalancer_clusters":           dataSourceClusters(),
+			"loadbalancer_haproxy_config":     dataSourceHAProxyConfig(),
+			"loadbalancer_listener":           dataSourceListener(),
+			"loadbalancer_listeners":          dataSourceListeners(),
+			"loadbalancer_target":             dataSourceTarget(),
+			"loadbalancer_targets":            dataSourceTargets(),
+			"loadbalancer_targetgroup":        dataSourceTargetGroup(),
+			"loadbalancer_targetgroups":       dataSourceTargetGroups(),
+			"loadbalancer_vip":                dataSourceVip(),
+			"loadbalancer_vips":               dataSourceVips(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"loadbalancer_accessip":    resourceAccessIP(),
-			"loadbalancer_acl":         resourceACL(),
-			"loadbalancer_bind":        resourceBind(),
-			"loadbalancer_certificate": resourceCertificate(),
-			"loadbalancer_cluster":     resourceCluster(),
-			"loadbalancer_listener":    resourceListener(),
-			"loadbalancer_target":      resourceTarget(),
-			"loadbalancer_targetgroup": resourceTargetGroup(),
+			"loadbalancer_accessip":            resourceAccessIP(),
+			"loadbalancer_acl":                 resourceACL(),
+			"loadbalancer_bind":                resourceBind(),
+			"loadbalancer_certificate":         resourceCertificate(),
+			"loadbalancer_cluster":             resourceCluster(),
+			"loadbalancer_cluster_deployment":  resourceClusterDeployment(),
+			"loadbalancer_cluster_replication": resourceClusterReplication(),
+			"loadbalancer_listener":            resourceListener(),
+			"loadbalancer_listener_acls":       resourceListenerACLs(),
+			"loadbalancer_service":             resourceService(),
+			"loadbalancer_target":              resourceTarget(),
+			"loadbalancer_targetgroup":         resourceTargetGroup(),
+			"loadbalancer_vip":                 resourceVip(),
 		},
 		ConfigureFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	return getService(d.Get("api_key").(string)), nil
-}
+	service := getService(d.Get("api_key").(string))
+
+	if autoDeployConfig, ok := d.GetOk("auto_deploy"); ok {
+		config, _ := autoDeployConfig.([]interface{})[0].(map[string]interface{})
+		if config == nil {
+			config = map[string]interface{}{"settle_delay": 10, "timeout": 900, "warn_on_failure": false}
+		}
+
+		return newAutoDeployService(
+			service,
+			time.Duration(config["settle_delay"].(int))*time.Second,
+			time.Duration(config["timeout"].(int))*time.Second,
+			config["warn_on_failure"].(bool),
+		), nil
+	}
 
-func getClient(apiKey string) client.Client {
-	return client.NewClient(connection.NewAPIKeyCredentialsAPIConnection(apiKey))
+	return service, nil
 }
 
-func getService(apiKey string) loadbalancerservice.LoadBalancerService {
-	return getClient(apiKey).LoadBalancerService()
+func getService(apiKey string) *providerService {
+	conn := connection.NewAPIKeyCredentialsAPIConnection(apiKey)
+
+	return newProviderService(conn, client.NewClient(conn).LoadBalancerService())
 }
 
 func setKeys(d *schema.ResourceData, kv map[string]any) diag.Diagnostics {
@@ -74,3 +140,24 @@ func setKeys(d *schema.ResourceData, kv map[string]any) diag.Diagnostics {
 	}
 	return nil
 }
+
+// importStateWithParentID returns an importer accepting IDs in the form {parent_id}/{id}, for
+// resources whose parent ID isn't returned by the API. IDs without a parent ID are passed through
+func importStateWithParentID(parentKey string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		rawParentID, id, ok := strings.Cut(d.Id(), "/")
+		if !ok {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		parentID, err := strconv.Atoi(rawParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		d.SetId(id)
+		err = d.Set(parentKey, parentID)
+
+		return []*schema.ResourceData{d}, err
+	}
+}
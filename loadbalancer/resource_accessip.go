@@ -14,12 +14,12 @@ import (
 
 func resourceAccessIP() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceAccessIPCreate,
+		CreateContext: autoDeploy(resourceAccessIPCreate, clusterIDFromListenerID),
 		ReadContext:   resourceAccessIPRead,
-		UpdateContext: resourceAccessIPUpdate,
-		DeleteContext: resourceAccessIPDelete,
+		UpdateContext: autoDeploy(resourceAccessIPUpdate, clusterIDFromListenerID),
+		DeleteContext: autoDeploy(resourceAccessIPDelete, clusterIDFromListenerID),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithParentID("listener_id"),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -3,23 +3,27 @@ package loadbalancer
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"strconv"
 
 	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceACL() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceACLCreate,
+		CreateContext: autoDeploy(resourceACLCreate, clusterIDFromListenerOrTargetGroupID),
 		ReadContext:   resourceACLRead,
-		UpdateContext: resourceACLUpdate,
-		DeleteContext: resourceACLDelete,
+		UpdateContext: autoDeploy(resourceACLUpdate, clusterIDFromListenerOrTargetGroupID),
+		DeleteContext: autoDeploy(resourceACLDelete, clusterIDFromListenerOrTargetGroupID),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceACLCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"listener_id": {
@@ -38,68 +42,88 @@ func resourceACL() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"condition": {
-				Type:     schema.TypeList,
-				MinItems: 1,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"argument": {
-							Type:     schema.TypeSet,
-							Required: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"value": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
-						},
-					},
-				},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
-			"action": {
+			"expression": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"condition"},
+				ValidateFunc:  validateACLExpression,
+			},
+			"condition": optionalComputed(resourceACLConditionSchema()),
+			"action":    optionalComputed(resourceACLActionSchema()),
+			"evaluation_order": {
 				Type:     schema.TypeList,
-				MinItems: 1,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"argument": {
-							Type:     schema.TypeSet,
-							Required: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"value": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
-						},
-					},
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
 				},
 			},
 		},
 	}
 }
 
+// optionalComputed makes s optional and computed, for attributes which may instead be derived
+// from another attribute
+func optionalComputed(s *schema.Schema) *schema.Schema {
+	s.Required = false
+	s.Optional = true
+	s.Computed = true
+
+	return s
+}
+
+func resourceACLConditionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MinItems: 1,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: aclBlockSchema(map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"inverted": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+				"argument": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     aclArgumentResource,
+				},
+			}, aclConditionBlocks),
+		},
+	}
+}
+
+func resourceACLActionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MinItems: 1,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: aclBlockSchema(map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"argument": {
+					Type:     schema.TypeSet,
+					Optional: true,
+					Elem:     aclArgumentResource,
+				},
+			}, aclActionBlocks),
+		},
+	}
+}
+
 func resourceACLCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
@@ -107,16 +131,30 @@ func resourceACLCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 		"listener_id":     d.Get("listener_id"),
 		"target_group_id": d.Get("target_group_id"),
 		"name":            d.Get("name"),
+		"priority":        d.Get("priority"),
 	})
 
 	createReq := loadbalancerservice.CreateACLRequest{
 		ListenerID:    d.Get("listener_id").(int),
 		TargetGroupID: d.Get("target_group_id").(int),
 		Name:          d.Get("name").(string),
+		Priority:      d.Get("priority").(int),
 		Conditions:    expandACLConditions(d.Get("condition").([]interface{})),
 		Actions:       expandACLActions(d.Get("action").([]interface{})),
 	}
 
+	if expression, ok := d.GetOk("expression"); ok {
+		conditions, actions, err := parseACLExpression(expression.(string))
+		if err != nil {
+			return diag.Errorf("Error parsing ACL expression: %s", err)
+		}
+
+		createReq.Conditions = conditions
+		if len(actions) > 0 {
+			createReq.Actions = actions
+		}
+	}
+
 	tflog.Debug(ctx, "created CreateACLRequest", map[string]any{
 		"create_acl_request": createReq,
 	})
@@ -132,7 +170,7 @@ func resourceACLCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 }
 
 func resourceACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	service := meta.(loadbalancerservice.LoadBalancerService)
+	service := meta.(aclManager)
 
 	aclID, _ := strconv.Atoi(d.Id())
 
@@ -140,7 +178,7 @@ func resourceACLRead(ctx context.Context, d *schema.ResourceData, meta interface
 		"acl_id": aclID,
 	})
 
-	acl, err := service.GetACL(aclID)
+	acl, err := service.GetACLWithPriority(aclID)
 	if err != nil {
 		var ACLNotFoundError *loadbalancerservice.ACLNotFoundError
 		switch {
@@ -152,15 +190,248 @@ func resourceACLRead(ctx context.Context, d *schema.ResourceData, meta interface
 		}
 	}
 
+	key, parentID := "listener_id", acl.ListenerID
+	if parentID == 0 {
+		key, parentID = "target_group_id", acl.TargetGroupID
+	}
+
+	acls, err := getACLsWithPriority(service, key, parentID)
+	if err != nil {
+		return diag.Errorf("Error retrieving ACLs for %s [%d]: %s", key, parentID, err)
+	}
+
 	return setKeys(d, map[string]any{
-		"listener_id":     acl.ListenerID,
-		"target_group_id": acl.TargetGroupID,
-		"name":            acl.Name,
-		"condition":       flattenACLConditions(acl.Conditions),
-		"action":          flattenACLActions(acl.Actions),
+		"listener_id":      acl.ListenerID,
+		"target_group_id":  acl.TargetGroupID,
+		"name":             acl.Name,
+		"priority":         acl.Priority,
+		"evaluation_order": aclEvaluationOrder(acls),
+		"condition":        flattenACLBlocks(flattenACLConditions(acl.Conditions), d.Get("condition").([]interface{}), aclConditionBlocks),
+		"action":           flattenACLBlocks(flattenACLActions(acl.Actions), d.Get("action").([]interface{}), aclActionBlocks),
 	})
 }
 
+func resourceACLCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffACLRules(ctx, d, meta); err != nil {
+		return err
+	}
+
+	return customizeDiffACLOrder(d, meta)
+}
+
+// customizeDiffACLOrder plans the evaluation order of the ACLs of the listener or target group
+// which results from the ACL's priority, where the ACL is created or its priority is changed.
+// The order is planned from the other ACLs as they currently are, so doesn't reflect changes to
+// them within the same apply
+func customizeDiffACLOrder(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("priority") && !d.HasChange("name") {
+		return nil
+	}
+
+	priority, _ := d.Get("priority").(int)
+	if priority == 0 || !d.NewValueKnown("priority") || !d.NewValueKnown("name") ||
+		!d.NewValueKnown("listener_id") || !d.NewValueKnown("target_group_id") {
+		return d.SetNewComputed("evaluation_order")
+	}
+
+	key := "listener_id"
+	if _, ok := d.GetOk("listener_id"); !ok {
+		key = "target_group_id"
+	}
+
+	acls, err := getACLsWithPriority(meta.(aclManager), key, d.Get(key).(int))
+	if err != nil {
+		return fmt.Errorf("Error retrieving ACLs for %s [%d]: %s", key, d.Get(key).(int), err)
+	}
+
+	aclID, _ := strconv.Atoi(d.Id())
+	planned := aclWithPriority{
+		ACL:      loadbalancerservice.ACL{ID: aclID, Name: d.Get("name").(string)},
+		Priority: priority,
+	}
+
+	acls = slices.DeleteFunc(acls, func(acl aclWithPriority) bool { return aclID != 0 && acl.ID == aclID })
+
+	return d.SetNew("evaluation_order", aclEvaluationOrder(append(acls, planned)))
+}
+
+// customizeDiffACLRules validates the conditions and actions of the ACL, or its expression
+func customizeDiffACLRules(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if expression, ok := d.GetOk("expression"); ok || !d.NewValueKnown("expression") {
+		// Actions are given by blocks where the expression has conditions alone
+		actionConfigured := aclBlocksConfigured(d, "action")
+
+		if !d.NewValueKnown("expression") {
+			return customizeDiffACLExpressionComputed(d, actionConfigured)
+		}
+
+		conditions, actions, err := parseACLExpression(expression.(string))
+		if err != nil {
+			return fmt.Errorf("expression: %s", err)
+		}
+
+		switch {
+		case len(actions) > 0 && actionConfigured:
+			return fmt.Errorf("action: can't be specified when expression includes actions")
+		case len(actions) == 0 && !actionConfigured:
+			return fmt.Errorf("action: at least one action must be specified when expression has conditions alone")
+		case len(actions) == 0:
+			if err := validateACLBlocks(d, "action", aclActionBlocks); err != nil {
+				return err
+			}
+
+			actions = expandACLActions(d.Get("action").([]interface{}))
+		}
+
+		if d.Id() != "" && !d.HasChange("expression") {
+			if err := customizeDiffACLExpressionDrift(d, conditions, actions, !actionConfigured); err != nil {
+				return err
+			}
+
+			// HasChange reports blocks holding sets as always changed, so actions are compared
+			priorActions, _ := d.GetChange("action")
+			if !actionConfigured || aclRulesEqual(flattenACLActions(expandACLActions(priorActions.([]interface{}))), flattenACLActions(actions)) {
+				return nil
+			}
+		} else if err := customizeDiffACLExpressionComputed(d, actionConfigured); err != nil {
+			return err
+		}
+
+		templates, err := getACLTemplatesForDiff(ctx, d, meta)
+		if err != nil || templates == nil {
+			return err
+		}
+
+		return validateACLTemplates("expression: ", *templates, conditions, actions)
+	}
+
+	if rawConfig := d.GetRawConfig(); !rawConfig.IsNull() {
+		for _, key := range []string{"condition", "action"} {
+			value := rawConfig.GetAttr(key)
+			if value.IsKnown() && (value.IsNull() || value.LengthInt() == 0) {
+				return fmt.Errorf("%s: at least one %s must be specified when expression is not set", key, key)
+			}
+		}
+	}
+
+	changed := d.Id() == "" || d.HasChange("condition") || d.HasChange("action")
+
+	return customizeDiffACLs(ctx, d, meta, changed, "")
+}
+
+// aclBlocksConfigured returns whether any blocks of key are configured, which is assumed where
+// the configuration isn't yet known
+func aclBlocksConfigured(d *schema.ResourceDiff, key string) bool {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return false
+	}
+
+	value := rawConfig.GetAttr(key)
+
+	return !value.IsKnown() || (!value.IsNull() && value.LengthInt() > 0)
+}
+
+// customizeDiffACLExpressionComputed plans the conditions, and the actions unless configured by
+// blocks, as computed, as they're read back from the API once the expression is applied
+func customizeDiffACLExpressionComputed(d *schema.ResourceDiff, actionConfigured bool) error {
+	if err := d.SetNewComputed("condition"); err != nil {
+		return err
+	}
+
+	if actionConfigured {
+		return nil
+	}
+
+	return d.SetNewComputed("action")
+}
+
+// customizeDiffACLExpressionDrift plans the conditions and actions parsed from the unchanged
+// expression where they differ from those read from the API, so changes made outside of
+// Terraform are reverted. Actions configured by blocks are planned as usual, so are only
+// compared where includeActions is set
+func customizeDiffACLExpressionDrift(d *schema.ResourceDiff, conditions []loadbalancerservice.ACLCondition, actions []loadbalancerservice.ACLAction, includeActions bool) error {
+	priorConditions := d.Get("condition").([]interface{})
+	priorActions := d.Get("action").([]interface{})
+
+	conditionsEqual := aclRulesEqual(flattenACLConditions(conditions), flattenACLConditions(expandACLConditions(priorConditions)))
+	actionsEqual := !includeActions || aclRulesEqual(flattenACLActions(actions), flattenACLActions(expandACLActions(priorActions)))
+	if conditionsEqual && actionsEqual {
+		return nil
+	}
+
+	if err := d.SetNew("condition", flattenACLBlocks(flattenACLConditions(conditions), priorConditions, aclConditionBlocks)); err != nil {
+		return err
+	}
+
+	if !includeActions {
+		return nil
+	}
+
+	return d.SetNew("action", flattenACLBlocks(flattenACLActions(actions), priorActions, aclActionBlocks))
+}
+
+// customizeDiffACLs validates the conditions and actions of each ACL, identified by the key
+// prefixes within d, against the ACL templates of the cluster when changed
+func customizeDiffACLs(ctx context.Context, d *schema.ResourceDiff, meta interface{}, changed bool, prefixes ...string) error {
+	for _, prefix := range prefixes {
+		if err := validateACLBlocks(d, prefix+"condition", aclConditionBlocks); err != nil {
+			return err
+		}
+
+		if err := validateACLBlocks(d, prefix+"action", aclActionBlocks); err != nil {
+			return err
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	templates, err := getACLTemplatesForDiff(ctx, d, meta)
+	if err != nil || templates == nil {
+		return err
+	}
+
+	var errs []error
+	for _, prefix := range prefixes {
+		errs = append(errs, validateACLTemplates(
+			prefix,
+			*templates,
+			expandACLConditions(d.Get(prefix+"condition").([]interface{})),
+			expandACLActions(d.Get(prefix+"action").([]interface{})),
+		))
+	}
+
+	return errors.Join(errs...)
+}
+
+// getACLTemplatesForDiff retrieves the ACL templates of the cluster the listener or target group
+// belongs to, or nil when the listener or target group isn't yet known
+func getACLTemplatesForDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) (*loadbalancerservice.ACLTemplates, error) {
+	if !d.NewValueKnown("listener_id") || !d.NewValueKnown("target_group_id") {
+		return nil, nil
+	}
+
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	clusterID, err := clusterIDFromListenerOrTargetGroupID(d, service)
+	if err != nil {
+		return nil, fmt.Errorf("Error resolving cluster for ACL validation: %s", err)
+	}
+
+	tflog.Debug(ctx, "validating ACLs against templates", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	templates, err := service.GetClusterACLTemplates(clusterID)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving ACL templates for cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	return &templates, nil
+}
+
 func resourceACLUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 	patchReq := loadbalancerservice.PatchACLRequest{}
@@ -171,12 +442,33 @@ func resourceACLUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		patchReq.Name = d.Get("name").(string)
 	}
 
-	if d.HasChange("condition") {
-		patchReq.Conditions = expandACLConditions(d.Get("condition").([]interface{}))
+	if d.HasChange("priority") {
+		patchReq.Priority = d.Get("priority").(int)
 	}
 
-	if d.HasChange("action") {
-		patchReq.Actions = expandACLActions(d.Get("action").([]interface{}))
+	if expression, ok := d.GetOk("expression"); ok {
+		// Conditions and actions change along with an unchanged expression where they've been
+		// changed outside of Terraform
+		if d.HasChanges("expression", "condition", "action") {
+			conditions, actions, err := parseACLExpression(expression.(string))
+			if err != nil {
+				return diag.Errorf("Error parsing ACL expression: %s", err)
+			}
+
+			patchReq.Conditions = conditions
+			patchReq.Actions = actions
+			if len(actions) == 0 {
+				patchReq.Actions = expandACLActions(d.Get("action").([]interface{}))
+			}
+		}
+	} else {
+		if d.HasChange("condition") {
+			patchReq.Conditions = expandACLConditions(d.Get("condition").([]interface{}))
+		}
+
+		if d.HasChange("action") {
+			patchReq.Actions = expandACLActions(d.Get("action").([]interface{}))
+		}
 	}
 
 	tflog.Info(ctx, "updating ACL", map[string]any{
@@ -0,0 +1,198 @@
+package loadbalancer
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceACLUpdatePriority(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceACL().Schema, map[string]interface{}{
+		"listener_id": 1,
+		"priority":    2,
+		"condition":   []interface{}{map[string]interface{}{"name": "path_begins_with"}},
+		"action":      []interface{}{map[string]interface{}{"name": "use_target_group"}},
+	})
+	d.SetId("10")
+
+	conn := &testConnection{
+		statusCode: 200,
+		body:       `{"data":[{"id":10,"listener_id":1,"priority":2},{"id":11,"name":"legacy","listener_id":1,"priority":1}],"meta":{"pagination":{"total_pages":1}}}`,
+		bodies: map[string]string{
+			"GET /loadbalancers/v2/acls/10": `{"data":{"id":10,"listener_id":1,"priority":2}}`,
+		},
+	}
+
+	diags := resourceACLUpdate(context.Background(), d, newProviderService(conn, loadbalancer.NewService(conn)))
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(conn.requestBodies) != 1 || !strings.Contains(conn.requestBodies[0], `"priority":2`) {
+		t.Errorf("expected priority to be patched, got %v", conn.requestBodies)
+	}
+
+	expected := []interface{}{"legacy", "acl 10"}
+	if order := d.Get("evaluation_order"); !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected evaluation order %v, got %v", expected, order)
+	}
+}
+
+func TestACLEvaluationOrder(t *testing.T) {
+	acls := []aclWithPriority{
+		{ACL: loadbalancer.ACL{ID: 12, Name: "static"}, Priority: 20},
+		{ACL: loadbalancer.ACL{Name: "inserted"}, Priority: 10},
+		{ACL: loadbalancer.ACL{ID: 11}, Priority: 10},
+		{ACL: loadbalancer.ACL{ID: 10, Name: "legacy"}, Priority: 5},
+	}
+
+	expected := []string{"legacy", "acl 11", "inserted", "static"}
+	if order := aclEvaluationOrder(acls); !reflect.DeepEqual(order, expected) {
+		t.Errorf("expected evaluation order %v, got %v", expected, order)
+	}
+}
+
+func TestResourceACLDiffExpressionDrift(t *testing.T) {
+	for name, tc := range map[string]struct {
+		path    string
+		changed bool
+	}{
+		"unchanged": {path: "/static", changed: false},
+		"drifted":   {path: "/assets", changed: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID: "10",
+				Attributes: map[string]string{
+					"id":                                  "10",
+					"listener_id":                         "1",
+					"priority":                            "1",
+					"expression":                          "use_backend 2 if path_beg /static",
+					"condition.#":                         "1",
+					"condition.0.inverted":                "false",
+					"condition.0.path_begins_with.#":      "1",
+					"condition.0.path_begins_with.0.path": tc.path,
+					"action.#":                            "1",
+					"action.0.use_target_group.#":         "1",
+					"action.0.use_target_group.0.target_group_id": "2",
+				},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"listener_id": 1,
+				"priority":    1,
+				"expression":  "use_backend 2 if path_beg /static",
+			})
+
+			diff, err := resourceACL().Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			attribute, _ := diff.GetAttribute("condition.0.path_begins_with.0.path")
+			if changed := attribute != nil && attribute.New == "/static"; changed != tc.changed {
+				t.Errorf("expected condition change %t, got %+v", tc.changed, diff)
+			}
+		})
+	}
+}
+
+func TestResourceACLDiffConditionOnlyExpression(t *testing.T) {
+	action := []interface{}{
+		map[string]interface{}{
+			"use_target_group": []interface{}{map[string]interface{}{"target_group_id": 2}},
+		},
+	}
+
+	// The condition has drifted from the expression outside of Terraform
+	d := schema.TestResourceDataRaw(t, resourceACL().Schema, map[string]interface{}{
+		"listener_id": 1,
+		"priority":    1,
+		"expression":  "path_beg /static",
+		"condition": []interface{}{
+			map[string]interface{}{
+				"path_begins_with": []interface{}{map[string]interface{}{"path": "/assets"}},
+			},
+		},
+		"action": action,
+	})
+	d.SetId("10")
+	state := d.State()
+
+	for name, tc := range map[string]struct {
+		config map[string]interface{}
+		err    string
+	}{
+		"action blocks": {
+			config: map[string]interface{}{"action": action},
+		},
+		"missing action": {
+			err: "action: at least one action must be specified when expression has conditions alone",
+		},
+		"actions in expression and blocks": {
+			config: map[string]interface{}{
+				"expression": "use_backend 2 if path_beg /static",
+				"action":     action,
+			},
+			err: "action: can't be specified when expression includes actions",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"listener_id": 1,
+				"priority":    1,
+				"expression":  "path_beg /static",
+			}
+			for k, v := range tc.config {
+				raw[k] = v
+			}
+
+			state := state.DeepCopy()
+			state.RawConfig = testRawConfig(t, resourceACL(), raw)
+
+			diff, err := resourceACL().Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+			if tc.err != "" {
+				if err == nil || err.Error() != tc.err {
+					t.Fatalf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			// The drifted condition is planned from the expression, with the action unchanged
+			attribute, _ := diff.GetAttribute("condition.0.path_begins_with.0.path")
+			if attribute == nil || attribute.New != "/static" {
+				t.Errorf("expected condition to be planned from the expression, got %+v", diff)
+			}
+
+			if attribute, _ := diff.GetAttribute("action.0.use_target_group.0.target_group_id"); attribute != nil {
+				t.Errorf("expected action to be unchanged, got %+v", attribute)
+			}
+		})
+	}
+}
+
+// testRawConfig returns the raw configuration of r from raw, as given by Terraform during plan.
+// Attributes missing from raw are null
+func testRawConfig(t *testing.T, r *schema.Resource, raw map[string]interface{}) cty.Value {
+	b, err := json.Marshal(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	value, err := ctyjson.Unmarshal(b, r.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return value
+}
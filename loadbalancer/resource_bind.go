@@ -14,10 +14,10 @@ import (
 
 func resourceBind() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceBindCreate,
+		CreateContext: autoDeploy(resourceBindCreate, clusterIDFromListenerID),
 		ReadContext:   resourceBindRead,
-		UpdateContext: resourceBindUpdate,
-		DeleteContext: resourceBindDelete,
+		UpdateContext: autoDeploy(resourceBindUpdate, clusterIDFromListenerID),
+		DeleteContext: autoDeploy(resourceBindDelete, clusterIDFromListenerID),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
 				ids := strings.Split(d.Id(), "/")
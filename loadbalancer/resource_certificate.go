@@ -3,22 +3,43 @@ package loadbalancer
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"strconv"
+	"time"
 
 	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// certificateKeyAttributes are the attributes a certificate's private key may be provided with.
+// Keys provided with key_wo, key_file or key_env aren't persisted to state
+var certificateKeyAttributes = []string{"key", "key_wo", "key_file", "key_env"}
+
+// certificateKeyTriggers are the attributes which change when a private key not persisted to
+// state should be sent to the API
+var certificateKeyTriggers = []string{"key", "key_wo_version", "key_file", "key_env"}
+
 func resourceCertificate() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceCertificateCreate,
+		CreateContext: autoDeploy(resourceCertificateCreate, clusterIDFromListenerID),
 		ReadContext:   resourceCertificateRead,
-		UpdateContext: resourceCertificateUpdate,
-		DeleteContext: resourceCertificateDelete,
+		UpdateContext: autoDeploy(resourceCertificateUpdate, clusterIDFromListenerID),
+		DeleteContext: autoDeploy(resourceCertificateDelete, clusterIDFromListenerID),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithParentID("listener_id"),
+		},
+		CustomizeDiff: resourceCertificateCustomizeDiff,
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validation.PreferWriteOnlyAttribute(cty.GetAttrPath("key"), cty.GetAttrPath("key_wo")),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Update: schema.DefaultTimeout(15 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,17 +53,98 @@ func resourceCertificate() *schema.Resource {
 				Required: true,
 			},
 			"key": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: certificateKeyAttributes,
+			},
+			"key_wo": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				WriteOnly:    true,
+				ExactlyOneOf: certificateKeyAttributes,
+			},
+			"key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: certificateKeyAttributes,
+			},
+			"key_env": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: certificateKeyAttributes,
+			},
+			"key_wo_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
 			},
 			"certificate": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCertificatePEM,
 			},
 			"ca_bundle": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"rotation_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "patch",
+				ValidateFunc: validation.StringInSlice([]string{"patch", "replace"}, false),
+			},
+			"rotation_deploy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"fingerprint_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"serial_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"not_before": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"not_after": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subject": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"issuer": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"days_until_expiry": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"ca_bundle_not_after": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -52,6 +154,11 @@ func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	listenerID := d.Get("listener_id").(int)
 
+	key, _, err := getCertificateKey(d)
+	if err != nil {
+		return diag.Errorf("Error retrieving certificate key: %s", err)
+	}
+
 	tflog.Info(ctx, "creating certificate", map[string]any{
 		"name":        d.Get("name"),
 		"listener_id": d.Get("listener_id"),
@@ -59,13 +166,14 @@ func resourceCertificateCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	createReq := loadbalancerservice.CreateCertificateRequest{
 		Name:        d.Get("name").(string),
-		Key:         d.Get("key").(string),
+		Key:         key,
 		Certificate: d.Get("certificate").(string),
 		CABundle:    d.Get("ca_bundle").(string),
 	}
 
 	tflog.Debug(ctx, "created CreateCertificateRequest", map[string]any{
-		"request": createReq,
+		"name":        createReq.Name,
+		"listener_id": listenerID,
 	})
 
 	certificate, err := service.CreateListenerCertificate(listenerID, createReq)
@@ -101,10 +209,42 @@ func resourceCertificateRead(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	return setKeys(d, map[string]any{
+	keys := map[string]any{
 		"listener_id": certificate.ListenerID,
 		"name":        certificate.Name,
-	})
+	}
+
+	// The API doesn't return key material, so the certificate within state is compared with the
+	// expiry returned by the API. Where they differ, the certificate has been replaced outside of
+	// Terraform, so the key material within state is cleared to report drift
+	certificatePEM := d.Get("certificate").(string)
+	expiresAt, expiresAtOK := parseAPIDateTime(ctx, certificate.ExpiresAt)
+
+	stateCertificate, err := parseCertificatePEM(certificatePEM)
+	if err == nil && expiresAtOK && !expiresAt.Equal(stateCertificate.NotAfter) {
+		tflog.Warn(ctx, "certificate expiry differs from state, certificate has been replaced outside of Terraform", map[string]any{
+			"certificate_id": certificateID,
+			"expires_at":     certificate.ExpiresAt.String(),
+			"state_expiry":   stateCertificate.NotAfter.Format(time.RFC3339),
+		})
+
+		certificatePEM = ""
+		keys["key"] = ""
+		keys["certificate"] = ""
+	}
+
+	for key, value := range flattenCertificateDetails(certificatePEM, d.Get("ca_bundle").(string)) {
+		keys[key] = value
+	}
+
+	// Without a certificate within state, such as once imported, the expiry is taken from the API
+	// so that it can still be checked until the certificate is next applied
+	if (err != nil || certificatePEM == "") && expiresAtOK {
+		keys["not_after"] = expiresAt.UTC().Format(time.RFC3339)
+		keys["days_until_expiry"] = daysUntil(expiresAt)
+	}
+
+	return setKeys(d, keys)
 }
 
 func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -114,12 +254,29 @@ func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta
 	certificateID, _ := strconv.Atoi(d.Id())
 	listenerID := d.Get("listener_id").(int)
 
+	keyChanged := d.HasChanges(certificateKeyTriggers...)
+
+	if !keyChanged && !d.HasChanges("name", "certificate", "ca_bundle") {
+		return resourceCertificateRead(ctx, d, meta)
+	}
+
+	if d.Get("rotation_strategy").(string) == "replace" && (keyChanged || d.HasChanges("certificate", "ca_bundle")) {
+		return resourceCertificateReplace(ctx, d, meta)
+	}
+
 	if d.HasChange("name") {
 		patchReq.Name = d.Get("name").(string)
 	}
 
-	if d.HasChange("key") {
-		patchReq.Key = d.Get("key").(string)
+	// The key is sent along with a changed certificate, as keys not persisted to state can't be
+	// compared with the prior key
+	if keyChanged || d.HasChange("certificate") {
+		key, _, err := getCertificateKey(d)
+		if err != nil {
+			return diag.Errorf("Error retrieving certificate key: %s", err)
+		}
+
+		patchReq.Key = key
 	}
 
 	if d.HasChange("certificate") {
@@ -144,6 +301,170 @@ func resourceCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta
 	return resourceCertificateRead(ctx, d, meta)
 }
 
+// certificateKeyConfig is satisfied by both *schema.ResourceData and *schema.ResourceDiff,
+// allowing keys to be validated during plan as well as sent during apply
+type certificateKeyConfig interface {
+	Get(key string) interface{}
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
+// getCertificateKey returns the private key of a certificate from whichever of key, key_file,
+// key_env or key_wo is configured, along with whether it is known. Files and environment
+// variables are read by the provider, so keys provided with them must be available to it
+func getCertificateKey(d certificateKeyConfig) (string, bool, error) {
+	if key := d.Get("key").(string); key != "" {
+		return key, true, nil
+	}
+
+	if path := d.Get("key_file").(string); path != "" {
+		key, err := os.ReadFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("key_file: %w", err)
+		}
+
+		return string(key), true, nil
+	}
+
+	if name := d.Get("key_env").(string); name != "" {
+		key := os.Getenv(name)
+		if key == "" {
+			return "", false, fmt.Errorf("key_env: environment variable [%s] isn't set", name)
+		}
+
+		return key, true, nil
+	}
+
+	// Write-only values are only available from configuration
+	key, diags := d.GetRawConfigAt(cty.GetAttrPath("key_wo"))
+	if diags.HasError() || key.IsNull() {
+		return "", true, nil
+	}
+
+	if !key.IsKnown() {
+		return "", false, nil
+	}
+
+	return key.AsString(), true, nil
+}
+
+// resourceCertificateReplace rotates a certificate by creating its replacement alongside it on the
+// listener, optionally deploying the cluster so traffic is served with the replacement, and only
+// then removing the replaced certificate
+func resourceCertificateReplace(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	certificateID, _ := strconv.Atoi(d.Id())
+	listenerID := d.Get("listener_id").(int)
+
+	key, _, err := getCertificateKey(d)
+	if err != nil {
+		return diag.Errorf("Error retrieving certificate key: %s", err)
+	}
+
+	tflog.Info(ctx, "creating replacement certificate", map[string]any{
+		"certificate_id": certificateID,
+		"listener_id":    listenerID,
+		"name":           d.Get("name"),
+	})
+
+	replacementID, err := service.CreateListenerCertificate(listenerID, loadbalancerservice.CreateCertificateRequest{
+		Name:        d.Get("name").(string),
+		Key:         key,
+		Certificate: d.Get("certificate").(string),
+		CABundle:    d.Get("ca_bundle").(string),
+	})
+	if err != nil {
+		return diag.Errorf("Error creating replacement for certificate with ID [%d]: %s", certificateID, err)
+	}
+
+	if d.Get("rotation_deploy").(bool) {
+		clusterID, err := clusterIDFromListenerID(d, service)
+		if err == nil {
+			_, err = deployCluster(ctx, service, clusterID, d.Timeout(schema.TimeoutUpdate))
+		}
+		if err != nil {
+			// The replaced certificate is retained, so the replacement is removed to leave the
+			// listener as it was
+			if removeErr := service.DeleteListenerCertificate(listenerID, replacementID); removeErr != nil {
+				return diag.Errorf("Error deploying replacement certificate with ID [%d]: %s, and error removing it: %s", replacementID, err, removeErr)
+			}
+
+			return diag.Errorf("Error deploying replacement certificate with ID [%d]: %s", replacementID, err)
+		}
+	}
+
+	// The replacement is tracked from here on, so that it is retained within state should the
+	// replaced certificate fail to be removed
+	d.SetId(strconv.Itoa(replacementID))
+
+	tflog.Info(ctx, "removing replaced certificate", map[string]any{
+		"certificate_id": certificateID,
+		"listener_id":    listenerID,
+	})
+
+	err = service.DeleteListenerCertificate(listenerID, certificateID)
+	if err != nil {
+		var certificateNotFoundError *loadbalancerservice.CertificateNotFoundError
+		if !errors.As(err, &certificateNotFoundError) {
+			return diag.Errorf("Error removing replaced certificate with ID [%d]: %s", certificateID, err)
+		}
+	}
+
+	return resourceCertificateRead(ctx, d, meta)
+}
+
+// resourceCertificateCustomizeDiff validates that the key and CA bundle belong with the
+// certificate, and plans the attributes parsed from a changed certificate or CA bundle so they
+// are known ahead of apply where the certificate and CA bundle are
+func resourceCertificateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChanges(append(certificateKeyTriggers, "certificate", "ca_bundle")...) {
+		return nil
+	}
+
+	known := d.NewValueKnown("certificate") && d.NewValueKnown("ca_bundle")
+
+	if known && d.NewValueKnown("key") && d.NewValueKnown("key_file") && d.NewValueKnown("key_env") {
+		key, keyKnown, err := getCertificateKey(d)
+		if err != nil {
+			return err
+		}
+
+		if keyKnown && key != "" {
+			if err := validateCertificateKeyPair(d.Get("certificate").(string), key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if known && d.Get("ca_bundle").(string) != "" {
+		if err := validateCertificateChain(d.Get("certificate").(string), d.Get("ca_bundle").(string)); err != nil {
+			return err
+		}
+	}
+
+	if !d.HasChanges("certificate", "ca_bundle") {
+		return nil
+	}
+
+	details := flattenCertificateDetails(d.Get("certificate").(string), d.Get("ca_bundle").(string))
+
+	for key, value := range details {
+		// days_until_expiry depends on when the certificate is read, so isn't planned, as a saved
+		// plan applied on a later day would otherwise be inconsistent with the applied result
+		var err error
+		if known && key != "days_until_expiry" {
+			err = d.SetNew(key, value)
+		} else {
+			err = d.SetNewComputed(key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resourceCertificateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	service := meta.(loadbalancerservice.LoadBalancerService)
 
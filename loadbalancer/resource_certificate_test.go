@@ -0,0 +1,306 @@
+package loadbalancer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// testCertificate returns a self-signed PEM encoded certificate and key valid until notAfter
+func testCertificate(t *testing.T, notAfter time.Time) (string, string) {
+	t.Helper()
+
+	certificate, key, _, _ := testIssueCertificate(t, notAfter, nil, nil)
+
+	return certificate, key
+}
+
+// testIssueCertificate returns a PEM encoded certificate and key valid until notAfter, issued by
+// parent or self-signed where parent is nil, along with the parsed certificate and key
+func testIssueCertificate(t *testing.T, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (string, string, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0x0102ab),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    notAfter.AddDate(-1, 0, 0),
+		NotAfter:     notAfter,
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+		template.Subject.CommonName = "Example CA"
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})),
+		certificate, key
+}
+
+// testCertificateConfig returns the configuration of a cluster with listener 1 holding certificate
+func testCertificateConfig(certificate loadbalancer.Certificate) clusterConfig {
+	return clusterConfig{
+		Listeners: []listenerConfig{
+			{
+				Listener:     loadbalancer.Listener{ID: 1},
+				Certificates: []loadbalancer.Certificate{certificate},
+			},
+		},
+	}
+}
+
+func TestResourceCertificateReadDrift(t *testing.T) {
+	notAfter := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	certificate, key := testCertificate(t, notAfter)
+
+	for name, expiresAt := range map[string]connection.DateTime{
+		"unchanged": "2030-01-01T00:00:00+00:00",
+		"replaced":  "2031-06-01T00:00:00+0000",
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceCertificate().Schema, map[string]interface{}{
+				"listener_id": 1,
+				"name":        "example.com",
+				"key":         key,
+				"certificate": certificate,
+			})
+			d.SetId("2")
+
+			service := newFakeService(t, testCertificateConfig(loadbalancer.Certificate{ID: 2, ListenerID: 1, Name: "example.com", ExpiresAt: expiresAt}))
+
+			diags := resourceCertificateRead(context.Background(), d, service)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if name == "unchanged" {
+				if d.Get("certificate") != certificate || d.Get("key") != key {
+					t.Errorf("expected key material to be retained")
+				}
+
+				if d.Get("serial_number") != "01:02:AB" || len(d.Get("fingerprint_sha256").(string)) != 95 {
+					t.Errorf("unexpected serial number [%s] or fingerprint [%s]", d.Get("serial_number"), d.Get("fingerprint_sha256"))
+				}
+
+				if d.Get("not_after") != "2030-01-01T00:00:00Z" || d.Get("subject") != "CN=Example CA" || d.Get("dns_names.0") != "example.com" {
+					t.Errorf("unexpected not_after [%s], subject [%s] or dns_names %v", d.Get("not_after"), d.Get("subject"), d.Get("dns_names"))
+				}
+			} else if d.Get("certificate") != "" || d.Get("key") != "" || d.Get("fingerprint_sha256") != "" {
+				t.Errorf("expected key material to be cleared for replaced certificate")
+			}
+		})
+	}
+}
+
+func TestResourceCertificateReadImported(t *testing.T) {
+	d := resourceCertificate().TestResourceData()
+	d.SetId("2")
+	if err := d.Set("listener_id", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	service := newFakeService(t, testCertificateConfig(loadbalancer.Certificate{ID: 2, ListenerID: 1, Name: "example.com", ExpiresAt: "2030-01-01T00:00:00+0000"}))
+
+	diags := resourceCertificateRead(context.Background(), d, service)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("name") != "example.com" || d.Get("not_after") != "2030-01-01T00:00:00Z" || d.Get("days_until_expiry").(int) <= 0 {
+		t.Errorf("expected expiry from the API, got not_after [%s] and days_until_expiry [%d]", d.Get("not_after"), d.Get("days_until_expiry"))
+	}
+
+	if d.Get("certificate") != "" || d.Get("fingerprint_sha256") != "" {
+		t.Errorf("expected certificate attributes to be empty until applied")
+	}
+}
+
+func TestCertificateValidation(t *testing.T) {
+	notAfter := time.Now().AddDate(1, 0, 0)
+
+	caPEM, _, ca, caKey := testIssueCertificate(t, notAfter, nil, nil)
+	otherCAPEM, _, _, _ := testIssueCertificate(t, notAfter, nil, nil)
+	leafPEM, leafKeyPEM, _, _ := testIssueCertificate(t, notAfter, ca, caKey)
+	_, otherKeyPEM := testCertificate(t, notAfter)
+
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519DER, err := x509.MarshalPKCS8PrivateKey(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519PEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ed25519DER}))
+	encryptedPEM := string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}}))
+
+	for name, tc := range map[string]struct {
+		err      error
+		expected string
+	}{
+		"matching key":     {validateCertificateKeyPair(leafPEM, leafKeyPEM), ""},
+		"mismatched key":   {validateCertificateKeyPair(leafPEM, otherKeyPEM), "key: private key doesn't match the public key of certificate [CN=example.com]"},
+		"encrypted key":    {validateCertificateKeyPair(leafPEM, encryptedPEM), "key: private key is encrypted, the unencrypted key must be provided"},
+		"unsupported key":  {validateCertificateKeyPair(leafPEM, ed25519PEM), "key: unsupported private key type ed25519.PrivateKey, expected an RSA or ECDSA key"},
+		"missing key":      {validateCertificateKeyPair(leafPEM, caPEM), "key: no PEM encoded private key found"},
+		"chained bundle":   {validateCertificateChain(leafPEM, caPEM), ""},
+		"unchained bundle": {validateCertificateChain(leafPEM, otherCAPEM), "ca_bundle: certificate [CN=example.com] issued by [CN=Example CA] doesn't chain to the CA bundle"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			switch {
+			case tc.expected == "" && tc.err != nil:
+				t.Errorf("unexpected error: %s", tc.err)
+			case tc.expected != "" && (tc.err == nil || !strings.HasPrefix(tc.err.Error(), tc.expected)):
+				t.Errorf("expected error [%s], got %v", tc.expected, tc.err)
+			}
+		})
+	}
+
+	expiredPEM, _ := testCertificate(t, time.Now().AddDate(0, 0, -1))
+	warnings, errs := validateCertificatePEM(expiredPEM, "certificate")
+	if len(errs) != 0 || len(warnings) != 1 || !strings.Contains(warnings[0], "certificate [CN=Example CA] expired at") {
+		t.Errorf("expected expiry warning, got %v %v", warnings, errs)
+	}
+}
+
+func TestResourceCertificateReplace(t *testing.T) {
+	certificate, key := testCertificate(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	d := schema.TestResourceDataRaw(t, resourceCertificate().Schema, map[string]interface{}{
+		"listener_id":       1,
+		"name":              "example.com",
+		"key":               key,
+		"certificate":       certificate,
+		"rotation_strategy": "replace",
+	})
+	d.SetId("2")
+
+	service := newFakeService(t, testCertificateConfig(loadbalancer.Certificate{ID: 3, ListenerID: 1, Name: "example.com", ExpiresAt: "2030-01-01T00:00:00+00:00"}))
+	service.nextID = 2
+
+	diags := resourceCertificateUpdate(context.Background(), d, service)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []string{"create certificate 3 example.com in listener 1", "delete certificate 2 in listener 1"}
+	if !reflect.DeepEqual(service.changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, service.changes)
+	}
+
+	if d.Id() != "3" {
+		t.Errorf("expected ID of replacement certificate, got %s", d.Id())
+	}
+}
+
+func TestResourceCertificateDiffExpiry(t *testing.T) {
+	certificate, key := testCertificate(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"listener_id": 1,
+		"name":        "example.com",
+		"certificate": certificate,
+		"key":         key,
+	})
+
+	diff, err := resourceCertificate().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if attribute, _ := diff.GetAttribute("not_after"); attribute == nil || attribute.New != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected not_after to be planned from the certificate, got %+v", attribute)
+	}
+
+	// days_until_expiry changes from day to day, so is left unknown for Read to compute on apply
+	if attribute, _ := diff.GetAttribute("days_until_expiry"); attribute == nil || !attribute.NewComputed {
+		t.Errorf("expected days_until_expiry to be computed on apply, got %+v", attribute)
+	}
+}
+
+func TestGetCertificateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "example.com.key")
+	if err := os.WriteFile(path, []byte("file key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TEST_CERTIFICATE_KEY", "environment key")
+
+	for name, tc := range map[string]struct {
+		config   map[string]interface{}
+		expected string
+		err      string
+	}{
+		"key":             {map[string]interface{}{"key": "state key"}, "state key", ""},
+		"key_file":        {map[string]interface{}{"key_file": path}, "file key", ""},
+		"key_env":         {map[string]interface{}{"key_env": "TEST_CERTIFICATE_KEY"}, "environment key", ""},
+		"missing key_env": {map[string]interface{}{"key_env": "TEST_CERTIFICATE_KEY_MISSING"}, "", "key_env: environment variable [TEST_CERTIFICATE_KEY_MISSING] isn't set"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceCertificate().Schema, tc.config)
+
+			key, _, err := getCertificateKey(d)
+			switch {
+			case tc.err != "" && (err == nil || err.Error() != tc.err):
+				t.Errorf("expected error [%s], got %v", tc.err, err)
+			case tc.err == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case key != tc.expected:
+				t.Errorf("expected key [%s], got [%s]", tc.expected, key)
+			}
+		})
+	}
+}
+
+func TestFlattenCertificateExpiry(t *testing.T) {
+	parsed := flattenCertificate(context.Background(), loadbalancer.Certificate{ExpiresAt: "2030-01-01T00:00:00+00:00"})
+	if parsed["not_after"] != "2030-01-01T00:00:00Z" || parsed["days_until_expiry"].(int) <= 0 {
+		t.Errorf("expected expiry to be parsed, got %v", parsed)
+	}
+
+	unparsed := flattenCertificate(context.Background(), loadbalancer.Certificate{ExpiresAt: "never"})
+	if _, ok := unparsed["days_until_expiry"]; ok || unparsed["not_after"] != "" {
+		t.Errorf("expected days_until_expiry to be unset, got %v", unparsed)
+	}
+}
@@ -15,12 +15,67 @@ func resourceCluster() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceClusterCreate,
 		ReadContext:   resourceClusterRead,
-		UpdateContext: resourceClusterUpdate,
+		UpdateContext: autoDeploy(resourceClusterUpdate, clusterIDFromID),
 		DeleteContext: resourceClusterDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceClusterImport,
 		},
 
+		// deletion_protection was added in version 1, and is enabled for existing clusters
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceClusterV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceClusterStateUpgradeV0,
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"deletion_protection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"deployed": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"deployed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_deployment_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_deployment_successful": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"updated_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// resourceClusterV0 returns the schema of the resource prior to deletion_protection
+func resourceClusterV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -30,8 +85,41 @@ func resourceCluster() *schema.Resource {
 	}
 }
 
+func resourceClusterStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	rawState["deletion_protection"] = true
+
+	return rawState, nil
+}
+
+// resourceClusterImport enables deletion_protection for imported clusters, as for clusters
+// upgraded from version 0, so removing an imported cluster never deprovisions it unexpectedly
+func resourceClusterImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	return []*schema.ResourceData{d}, d.Set("deletion_protection", true)
+}
+
 func resourceClusterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return diag.Errorf("The loadbalancer_cluster resource can only be imported at this time")
+	service := meta.(clusterManager)
+
+	createReq := createClusterRequest{
+		Name: d.Get("name").(string),
+	}
+
+	tflog.Debug(ctx, "created CreateClusterRequest", map[string]any{
+		"request": createReq,
+	})
+
+	tflog.Info(ctx, "creating cluster", map[string]any{
+		"name": createReq.Name,
+	})
+
+	clusterID, err := service.CreateCluster(createReq)
+	if err != nil {
+		return diag.Errorf("Error creating cluster: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(clusterID))
+
+	return resourceClusterRead(ctx, d, meta)
 }
 
 func resourceClusterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -55,9 +143,29 @@ func resourceClusterRead(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 	}
 
-	return setKeys(d, map[string]any{
-		"name": cluster.Name,
-	})
+	deployment, err := getLatestClusterDeployment(service, clusterID)
+	if err != nil {
+		return diag.Errorf("Error retrieving deployments for cluster with ID [%d]: %s", clusterID, err)
+	}
+
+	flattenedCluster := flattenCluster(cluster, deployment)
+	flattenedCluster["cluster_id"] = cluster.ID
+
+	return setKeys(d, flattenedCluster)
+}
+
+// flattenCluster returns the attributes of a cluster and its most recent deployment, common to
+// the loadbalancer_cluster resource and data source
+func flattenCluster(cluster loadbalancerservice.Cluster, deployment loadbalancerservice.Deployment) map[string]any {
+	return map[string]any{
+		"name":                       cluster.Name,
+		"deployed":                   cluster.Deployed,
+		"deployed_at":                cluster.DeployedAt.String(),
+		"last_deployment_id":         deployment.ID,
+		"last_deployment_successful": deployment.Successful,
+		"created_at":                 cluster.CreatedAt.String(),
+		"updated_at":                 cluster.UpdatedAt.String(),
+	}
 }
 
 func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -68,20 +176,41 @@ func resourceClusterUpdate(ctx context.Context, d *schema.ResourceData, meta int
 
 	if d.HasChange("name") {
 		patchReq.Name = d.Get("name").(string)
-	}
 
-	tflog.Info(ctx, "updating cluster", map[string]any{
-		"cluster_id": clusterID,
-	})
+		tflog.Info(ctx, "updating cluster", map[string]any{
+			"cluster_id": clusterID,
+		})
 
-	err := service.PatchCluster(clusterID, patchReq)
-	if err != nil {
-		return diag.Errorf("Error updating cluster with ID [%d]: %s", clusterID, err)
+		err := service.PatchCluster(clusterID, patchReq)
+		if err != nil {
+			return diag.Errorf("Error updating cluster with ID [%d]: %s", clusterID, err)
+		}
 	}
 
 	return resourceClusterRead(ctx, d, meta)
 }
 
 func resourceClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(clusterManager)
+
+	clusterID, _ := strconv.Atoi(d.Id())
+
+	if d.Get("deletion_protection").(bool) {
+		return diag.Errorf("Cannot remove cluster with ID [%d]: deletion_protection is enabled. "+
+			"Set deletion_protection to false and apply before removing the cluster", clusterID)
+	}
+
+	tflog.Info(ctx, "removing cluster", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	err := service.DeleteCluster(clusterID)
+	if err != nil {
+		var clusterNotFoundError *loadbalancerservice.ClusterNotFoundError
+		if !errors.As(err, &clusterNotFoundError) {
+			return diag.Errorf("Error removing cluster with ID [%d]: %s", clusterID, err)
+		}
+	}
+
 	return nil
 }
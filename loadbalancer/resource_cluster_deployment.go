@@ -0,0 +1,120 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceClusterDeployment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterDeploymentCreate,
+		ReadContext:   resourceClusterDeploymentRead,
+		UpdateContext: resourceClusterDeploymentUpdate,
+		DeleteContext: resourceClusterDeploymentDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"deployment_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"deployed_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceClusterDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	clusterID := d.Get("cluster_id").(int)
+
+	deployment, err := deployCluster(ctx, service, clusterID, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(clusterID))
+	d.Set("deployment_id", deployment.ID)
+
+	return resourceClusterDeploymentRead(ctx, d, meta)
+}
+
+func resourceClusterDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	clusterID, _ := strconv.Atoi(d.Id())
+
+	tflog.Debug(ctx, "retrieving cluster", map[string]any{
+		"cluster_id": clusterID,
+	})
+
+	cluster, err := service.GetCluster(clusterID)
+	if err != nil {
+		var clusterNotFoundError *loadbalancerservice.ClusterNotFoundError
+		switch {
+		case errors.As(err, &clusterNotFoundError):
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	return setKeys(d, map[string]any{
+		"cluster_id":  cluster.ID,
+		"deployed_at": cluster.DeployedAt.String(),
+	})
+}
+
+func resourceClusterDeploymentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	clusterID, _ := strconv.Atoi(d.Id())
+
+	if d.HasChange("triggers") {
+		deployment, err := deployCluster(ctx, service, clusterID, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			// Retain the previous triggers so that the deployment is retried on the next apply
+			d.Partial(true)
+			return diag.FromErr(err)
+		}
+
+		d.Set("deployment_id", deployment.ID)
+	}
+
+	return resourceClusterDeploymentRead(ctx, d, meta)
+}
+
+func resourceClusterDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tflog.Info(ctx, "removing cluster deployment from state", map[string]any{
+		"cluster_id": d.Get("cluster_id"),
+	})
+
+	return nil
+}
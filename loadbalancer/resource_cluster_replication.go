@@ -0,0 +1,257 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceClusterReplication() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: autoDeployClusters(resourceClusterReplicationCreate, replicaClusterIDs),
+		ReadContext:   resourceClusterReplicationRead,
+		UpdateContext: autoDeployClusters(resourceClusterReplicationUpdate, replicaClusterIDs),
+		DeleteContext: resourceClusterReplicationDelete,
+		CustomizeDiff: resourceClusterReplicationCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"source_cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"replica_cluster_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"vip_mapping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_vip_id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"replica_vip_id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"replica": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_sync": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"drift": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceClusterReplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(strconv.Itoa(d.Get("source_cluster_id").(int)))
+
+	diags := resourceClusterReplicationReconcile(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceClusterReplicationRead(ctx, d, meta)
+}
+
+func resourceClusterReplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(clusterConfigService)
+
+	sourceClusterID := d.Get("source_cluster_id").(int)
+
+	tflog.Debug(ctx, "retrieving source cluster configuration", map[string]any{
+		"cluster_id": sourceClusterID,
+	})
+
+	source, err := getReplicationSource(service, sourceClusterID)
+	if err != nil {
+		var clusterNotFoundError *loadbalancerservice.ClusterNotFoundError
+		if errors.As(err, &clusterNotFoundError) {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.Errorf("Error retrieving configuration for cluster with ID [%d]: %s", sourceClusterID, err)
+	}
+
+	vipMapping := expandVIPMapping(d)
+
+	var replicas []map[string]interface{}
+	for _, replicaID := range replicaClusterIDs(d) {
+		tflog.Debug(ctx, "comparing replica cluster configuration", map[string]any{
+			"cluster_id": replicaID,
+		})
+
+		var drift []string
+		changes, err := planReplication(ctx, service, source, replicaID, vipMapping)
+		if err != nil {
+			var clusterNotFoundError *loadbalancerservice.ClusterNotFoundError
+			var unmappedVIPError *unmappedVIPError
+			var clearedFieldsError *clearedFieldsError
+			switch {
+			case errors.As(err, &clusterNotFoundError):
+				drift = append(drift, "cluster not found")
+			case errors.As(err, &unmappedVIPError), errors.As(err, &clearedFieldsError):
+				drift = append(drift, err.Error())
+			default:
+				return diag.Errorf("Error comparing configuration of cluster with ID [%d]: %s", replicaID, err)
+			}
+		}
+
+		for _, change := range changes {
+			drift = append(drift, change.description)
+		}
+
+		replicas = append(replicas, map[string]interface{}{
+			"cluster_id": replicaID,
+			"in_sync":    len(drift) == 0,
+			"drift":      drift,
+		})
+	}
+
+	return setKeys(d, map[string]any{
+		"replica": replicas,
+	})
+}
+
+func resourceClusterReplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := resourceClusterReplicationReconcile(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceClusterReplicationRead(ctx, d, meta)
+}
+
+// resourceClusterReplicationDelete stops replication, leaving the configuration of replica
+// clusters as it is
+func resourceClusterReplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceClusterReplicationCustomizeDiff plans an update where any replica has drifted from the
+// source cluster, so that the drift is reconciled on apply
+func resourceClusterReplicationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.NewValueKnown("source_cluster_id") && d.NewValueKnown("replica_cluster_ids") {
+		sourceClusterID := d.Get("source_cluster_id").(int)
+		if slices.Contains(replicaClusterIDs(d), sourceClusterID) {
+			return fmt.Errorf("replica_cluster_ids: source cluster with ID [%d] can't be a replica of itself", sourceClusterID)
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("replica_cluster_ids") || d.HasChange("vip_mapping") {
+		return d.SetNewComputed("replica")
+	}
+
+	for _, rawReplica := range d.Get("replica").([]interface{}) {
+		replica, ok := rawReplica.(map[string]interface{})
+		if ok && !replica["in_sync"].(bool) {
+			return d.SetNewComputed("replica")
+		}
+	}
+
+	return nil
+}
+
+// resourceClusterReplicationReconcile applies the changes required for each replica cluster to
+// match the source cluster
+func resourceClusterReplicationReconcile(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(clusterConfigService)
+
+	sourceClusterID := d.Get("source_cluster_id").(int)
+
+	source, err := getReplicationSource(service, sourceClusterID)
+	if err != nil {
+		return diag.Errorf("Error retrieving configuration for cluster with ID [%d]: %s", sourceClusterID, err)
+	}
+
+	vipMapping := expandVIPMapping(d)
+
+	for _, replicaID := range replicaClusterIDs(d) {
+		tflog.Info(ctx, "replicating cluster configuration", map[string]any{
+			"source_cluster_id": sourceClusterID,
+			"cluster_id":        replicaID,
+		})
+
+		err := reconcileReplica(ctx, service, source, replicaID, vipMapping)
+		if err != nil {
+			return diag.Errorf("Error replicating to cluster with ID [%d]: %s", replicaID, err)
+		}
+	}
+
+	return nil
+}
+
+func getReplicationSource(service clusterConfigService, clusterID int) (clusterSnapshot, error) {
+	config, err := getClusterConfig(service, clusterID)
+	if err != nil {
+		return clusterSnapshot{}, err
+	}
+
+	return newClusterSnapshot(config), nil
+}
+
+// replicaClusterIDs returns the configured replica cluster IDs in ascending order
+func replicaClusterIDs(d resourceGetter) []int {
+	var clusterIDs []int
+	for _, clusterID := range d.Get("replica_cluster_ids").(*schema.Set).List() {
+		clusterIDs = append(clusterIDs, clusterID.(int))
+	}
+
+	sort.Ints(clusterIDs)
+
+	return clusterIDs
+}
+
+// expandVIPMapping returns the replica VIP IDs mapped to each source VIP ID, which may include
+// VIPs of several replicas
+func expandVIPMapping(d *schema.ResourceData) map[int][]int {
+	vipMapping := make(map[int][]int)
+	for _, rawMapping := range d.Get("vip_mapping").(*schema.Set).List() {
+		mapping := rawMapping.(map[string]interface{})
+		sourceVIPID := mapping["source_vip_id"].(int)
+		vipMapping[sourceVIPID] = append(vipMapping[sourceVIPID], mapping["replica_vip_id"].(int))
+	}
+
+	return vipMapping
+}
@@ -0,0 +1,77 @@
+package loadbalancer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceClusterDelete(t *testing.T) {
+	for name, tc := range map[string]struct {
+		deletionProtection bool
+		expected           []string
+	}{
+		"protected":   {true, nil},
+		"unprotected": {false, []string{"DELETE /loadbalancers/v2/clusters/1"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceCluster().Schema, map[string]interface{}{
+				"name":                "cluster-1",
+				"deletion_protection": tc.deletionProtection,
+			})
+			d.SetId("1")
+
+			conn := &testConnection{statusCode: 204}
+
+			diags := resourceClusterDelete(context.Background(), d, newProviderService(conn, nil))
+			if diags.HasError() != tc.deletionProtection {
+				t.Errorf("unexpected diagnostics %v", diags)
+			}
+
+			if !reflect.DeepEqual(conn.requests, tc.expected) {
+				t.Errorf("expected requests %v, got %v", tc.expected, conn.requests)
+			}
+		})
+	}
+}
+
+func TestResourceClusterStateUpgradeV0(t *testing.T) {
+	state, err := resourceClusterStateUpgradeV0(context.Background(), map[string]interface{}{"id": "1", "name": "cluster-1"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if state["deletion_protection"] != true {
+		t.Errorf("expected deletion protection to be enabled for existing clusters, got %v", state)
+	}
+}
+
+func TestResourceClusterImport(t *testing.T) {
+	d := resourceCluster().Data(nil)
+	d.SetId("1")
+
+	imported, err := resourceCluster().Importer.StateContext(context.Background(), d, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(imported) != 1 || imported[0].Get("deletion_protection") != true {
+		t.Errorf("expected deletion protection to be enabled for imported clusters")
+	}
+}
+
+func TestResourceClusterValidateDeploymentSettings(t *testing.T) {
+	// Only the name is sent when creating a cluster, so deployment settings can't be configured
+	for _, key := range []string{"deployed", "deployed_at", "last_deployment_id", "last_deployment_successful"} {
+		diags := resourceCluster().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "cluster-1",
+			key:    resourceCluster().Schema[key].ZeroValue(),
+		}))
+		if !diags.HasError() {
+			t.Errorf("expected %s to be rejected", key)
+		}
+	}
+}
@@ -14,10 +14,10 @@ import (
 
 func resourceListener() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceListenerCreate,
+		CreateContext: autoDeploy(resourceListenerCreate, clusterIDFromClusterID),
 		ReadContext:   resourceListenerRead,
-		UpdateContext: resourceListenerUpdate,
-		DeleteContext: resourceListenerDelete,
+		UpdateContext: autoDeploy(resourceListenerUpdate, clusterIDFromClusterID),
+		DeleteContext: autoDeploy(resourceListenerDelete, clusterIDFromClusterID),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
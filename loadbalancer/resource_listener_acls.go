@@ -0,0 +1,327 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceListenerACLs() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: autoDeploy(resourceListenerACLsCreate, clusterIDFromListenerOrTargetGroupID),
+		ReadContext:   resourceListenerACLsRead,
+		UpdateContext: autoDeploy(resourceListenerACLsUpdate, clusterIDFromListenerOrTargetGroupID),
+		DeleteContext: autoDeploy(resourceListenerACLsDelete, clusterIDFromListenerOrTargetGroupID),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceListenerACLsImport,
+		},
+		CustomizeDiff: resourceListenerACLsCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"listener_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"listener_id", "target_group_id"},
+			},
+			"target_group_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"listener_id", "target_group_id"},
+			},
+			"acl": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"priority": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"condition": resourceACLConditionSchema(),
+						"action":    resourceACLActionSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceListenerACLsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	parent := fmt.Sprintf("target group with ID [%d]", d.Get("target_group_id").(int))
+	if listenerID, ok := d.GetOk("listener_id"); ok {
+		parent = fmt.Sprintf("listener with ID [%d]", listenerID.(int))
+	}
+
+	// Existing ACLs aren't adopted, as converging would remove or rewrite them without them
+	// having been shown by the plan
+	existing, err := getListenerACLs(d, meta.(aclManager))
+	if err != nil {
+		return diag.Errorf("Error retrieving ACLs: %s", err)
+	}
+
+	if len(existing) > 0 {
+		var ids []string
+		for _, acl := range existing {
+			ids = append(ids, strconv.Itoa(acl.ID))
+		}
+
+		return diag.Errorf("Error creating ACLs: %s already has ACLs with IDs [%s], which must be removed, "+
+			"or adopted by importing this resource", parent, strings.Join(ids, ", "))
+	}
+
+	if listenerID, ok := d.GetOk("listener_id"); ok {
+		d.SetId(fmt.Sprintf("listener/%d", listenerID.(int)))
+	} else {
+		d.SetId(fmt.Sprintf("target_group/%d", d.Get("target_group_id").(int)))
+	}
+
+	diags := resourceListenerACLsConverge(ctx, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceListenerACLsRead(ctx, d, meta)
+}
+
+func resourceListenerACLsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(aclManager)
+
+	tflog.Debug(ctx, "retrieving ACLs", map[string]any{
+		"listener_id":     d.Get("listener_id"),
+		"target_group_id": d.Get("target_group_id"),
+	})
+
+	acls, err := getListenerACLs(d, service)
+	if err != nil {
+		return diag.Errorf("Error retrieving ACLs: %s", err)
+	}
+
+	// ACLs which aren't within configuration, such as those added outside of Terraform, are
+	// included so they are reported as drift
+	prior := d.Get("acl").([]interface{})
+
+	var flattenedACLs []map[string]interface{}
+	for i, acl := range acls {
+		var priorConditions, priorActions []interface{}
+		if i < len(prior) {
+			if priorACL, ok := prior[i].(map[string]interface{}); ok {
+				priorConditions, _ = priorACL["condition"].([]interface{})
+				priorActions, _ = priorACL["action"].([]interface{})
+			}
+		}
+
+		flattenedACLs = append(flattenedACLs, map[string]interface{}{
+			"id":        acl.ID,
+			"name":      acl.Name,
+			"priority":  acl.Priority,
+			"condition": flattenACLBlocks(flattenACLConditions(acl.Conditions), priorConditions, aclConditionBlocks),
+			"action":    flattenACLBlocks(flattenACLActions(acl.Actions), priorActions, aclActionBlocks),
+		})
+	}
+
+	return setKeys(d, map[string]any{
+		"acl": flattenedACLs,
+	})
+}
+
+func resourceListenerACLsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("acl") {
+		diags := resourceListenerACLsConverge(ctx, d, meta)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceListenerACLsRead(ctx, d, meta)
+}
+
+func resourceListenerACLsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	for _, rawACL := range d.Get("acl").([]interface{}) {
+		aclID := rawACL.(map[string]interface{})["id"].(int)
+		if aclID < 1 {
+			continue
+		}
+
+		tflog.Info(ctx, "removing ACL", map[string]any{
+			"acl_id": aclID,
+		})
+
+		err := service.DeleteACL(aclID)
+		if err != nil {
+			var aclNotFoundError *loadbalancerservice.ACLNotFoundError
+			if errors.As(err, &aclNotFoundError) {
+				continue
+			}
+
+			return diag.Errorf("Error removing ACL with ID [%d]: %s", aclID, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceListenerACLsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	kind, rawID, _ := strings.Cut(d.Id(), "/")
+
+	id, err := strconv.Atoi(rawID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID [%s], expected listener/<listener_id> or target_group/<target_group_id>", d.Id())
+	}
+
+	switch kind {
+	case "listener":
+		err = d.Set("listener_id", id)
+	case "target_group":
+		err = d.Set("target_group_id", id)
+	default:
+		return nil, fmt.Errorf("invalid import ID [%s], expected listener/<listener_id> or target_group/<target_group_id>", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, err
+}
+
+func resourceListenerACLsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var prefixes []string
+	for i := range d.Get("acl").([]interface{}) {
+		prefixes = append(prefixes, fmt.Sprintf("acl.%d.", i))
+	}
+
+	return customizeDiffACLs(ctx, d, meta, d.Id() == "" || d.HasChange("acl"), prefixes...)
+}
+
+// resourceListenerACLsConverge creates, patches and removes ACLs so that the ACLs of the listener
+// or target group match configuration. ACLs are assigned priorities in the order they are
+// configured, starting from 1
+func resourceListenerACLsConverge(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	existing, err := getListenerACLs(d, meta.(aclManager))
+	if err != nil {
+		return diag.Errorf("Error retrieving ACLs: %s", err)
+	}
+
+	var desired []aclWithPriority
+	for i, rawACL := range d.Get("acl").([]interface{}) {
+		acl := rawACL.(map[string]interface{})
+
+		desired = append(desired, aclWithPriority{
+			ACL: loadbalancerservice.ACL{
+				Name:          acl["name"].(string),
+				ListenerID:    d.Get("listener_id").(int),
+				TargetGroupID: d.Get("target_group_id").(int),
+				Conditions:    expandACLConditions(acl["condition"].([]interface{})),
+				Actions:       expandACLActions(acl["action"].([]interface{})),
+			},
+			Priority: i + 1,
+		})
+	}
+
+	if err := convergeACLs(ctx, service, desired, existing); err != nil {
+		return diag.Errorf("Error updating ACLs: %s", err)
+	}
+
+	return nil
+}
+
+// convergeACLs creates, patches and removes ACLs so that existing matches desired, which must
+// each have their listener or target group set
+func convergeACLs(ctx context.Context, service loadbalancerservice.LoadBalancerService, desired []aclWithPriority, existing []aclWithPriority) error {
+	matches, unmatched := matchACLs(desired, existing)
+
+	for _, acl := range unmatched {
+		tflog.Info(ctx, "removing ACL", map[string]any{
+			"acl_id": acl.ID,
+		})
+
+		err := service.DeleteACL(acl.ID)
+		if err != nil {
+			return fmt.Errorf("error removing ACL with ID [%d]: %w", acl.ID, err)
+		}
+	}
+
+	for i, acl := range desired {
+		match := matches[i]
+		if match == nil {
+			tflog.Info(ctx, "creating ACL", map[string]any{
+				"listener_id":     acl.ListenerID,
+				"target_group_id": acl.TargetGroupID,
+				"name":            acl.Name,
+				"priority":        acl.Priority,
+			})
+
+			_, err := service.CreateACL(loadbalancerservice.CreateACLRequest{
+				ListenerID:    acl.ListenerID,
+				TargetGroupID: acl.TargetGroupID,
+				Name:          acl.Name,
+				Priority:      acl.Priority,
+				Conditions:    acl.Conditions,
+				Actions:       acl.Actions,
+			})
+			if err != nil {
+				return fmt.Errorf("error creating ACL: %w", err)
+			}
+
+			continue
+		}
+
+		if aclsEqual(acl, *match) {
+			continue
+		}
+
+		tflog.Info(ctx, "updating ACL", map[string]any{
+			"acl_id":   match.ID,
+			"priority": acl.Priority,
+		})
+
+		err := service.PatchACL(match.ID, loadbalancerservice.PatchACLRequest{
+			Name:       acl.Name,
+			Priority:   acl.Priority,
+			Conditions: acl.Conditions,
+			Actions:    acl.Actions,
+		})
+		if err != nil {
+			return fmt.Errorf("error updating ACL with ID [%d]: %w", match.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// getListenerACLs retrieves the ACLs of the listener or target group, ordered by priority
+func getListenerACLs(d *schema.ResourceData, service aclManager) ([]aclWithPriority, error) {
+	params := connection.APIRequestParameters{}
+	if listenerID, ok := d.GetOk("listener_id"); ok {
+		params.WithFilter(*connection.NewAPIRequestFiltering("listener_id", connection.EQOperator, []string{strconv.Itoa(listenerID.(int))}))
+	} else {
+		params.WithFilter(*connection.NewAPIRequestFiltering("target_group_id", connection.EQOperator, []string{strconv.Itoa(d.Get("target_group_id").(int))}))
+	}
+
+	acls, err := service.GetACLsWithPriority(params)
+	if err != nil {
+		return nil, err
+	}
+
+	sortACLsByPriority(acls)
+
+	return acls, nil
+}
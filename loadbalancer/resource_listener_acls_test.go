@@ -0,0 +1,153 @@
+package loadbalancer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testListenerACLsConfig returns configuration of listener 1 with an ACL for each name, each
+// forwarding requests with a path prefix to target group 2
+func testListenerACLsConfig(names ...string) map[string]interface{} {
+	var acls []interface{}
+	for _, name := range names {
+		acls = append(acls, map[string]interface{}{
+			"name": name,
+			"condition": []interface{}{
+				map[string]interface{}{
+					"path_begins_with": []interface{}{map[string]interface{}{"path": "/" + name}},
+				},
+			},
+			"action": []interface{}{
+				map[string]interface{}{
+					"use_target_group": []interface{}{map[string]interface{}{"target_group_id": 2}},
+				},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"listener_id": 1,
+		"acl":         acls,
+	}
+}
+
+func TestResourceListenerACLsCreate(t *testing.T) {
+	service := newFakeService(t, clusterConfig{
+		Listeners: []listenerConfig{{Listener: loadbalancer.Listener{ID: 1}}},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceListenerACLs().Schema, testListenerACLsConfig("static", "api"))
+	if diags := resourceListenerACLsCreate(context.Background(), d, service); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	if expected := []string{"create ACL 1 static", "create ACL 2 api"}; !reflect.DeepEqual(service.changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, service.changes)
+	}
+
+	for i, acl := range service.acls {
+		if acl.ListenerID != 1 || acl.Priority != i+1 {
+			t.Errorf("expected ACL %d to be created on listener 1 with priority %d, got %+v", i, i+1, acl)
+		}
+	}
+
+	if d.Id() != "listener/1" {
+		t.Errorf("expected ID listener/1, got %s", d.Id())
+	}
+}
+
+func TestResourceListenerACLsCreateExisting(t *testing.T) {
+	service := newFakeService(t, clusterConfig{
+		Listeners: []listenerConfig{
+			{
+				Listener: loadbalancer.Listener{ID: 1},
+				ACLs: []aclWithPriority{
+					{ACL: loadbalancer.ACL{ID: 10, ListenerID: 1, Name: "static"}, Priority: 1},
+					{ACL: loadbalancer.ACL{ID: 11, ListenerID: 1, Name: "legacy"}, Priority: 2},
+				},
+			},
+		},
+	})
+
+	// ACLs which already exist aren't adopted, even where they match configuration by name
+	d := schema.TestResourceDataRaw(t, resourceListenerACLs().Schema, testListenerACLsConfig("static"))
+	diags := resourceListenerACLsCreate(context.Background(), d, service)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "IDs [10, 11]") {
+		t.Fatalf("expected error reporting existing ACLs, got %v", diags)
+	}
+
+	if len(service.changes) != 0 || d.Id() != "" {
+		t.Errorf("expected no changes, got %v with ID %q", service.changes, d.Id())
+	}
+}
+
+func TestResourceListenerACLsConverge(t *testing.T) {
+	// The existing ACLs match configuration of static, legacy and api in that order
+	var existing []aclWithPriority
+	current := schema.TestResourceDataRaw(t, resourceListenerACLs().Schema, testListenerACLsConfig("static", "legacy", "api"))
+	for i, rawACL := range current.Get("acl").([]interface{}) {
+		acl := rawACL.(map[string]interface{})
+
+		existing = append(existing, aclWithPriority{
+			ACL: loadbalancer.ACL{
+				ID:         10 + i,
+				ListenerID: 1,
+				Name:       acl["name"].(string),
+				Conditions: expandACLConditions(acl["condition"].([]interface{})),
+				Actions:    expandACLActions(acl["action"].([]interface{})),
+			},
+			Priority: i + 1,
+		})
+	}
+
+	tests := []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{
+			name:     "patch",
+			names:    []string{"api", "static", "legacy"},
+			expected: []string{"patch ACL 12 api priority 1", "patch ACL 10 static priority 2", "patch ACL 11 legacy priority 3"},
+		},
+		{
+			name:     "delete",
+			names:    []string{"static", "api"},
+			expected: []string{"delete ACL 11", "patch ACL 12 api priority 2"},
+		},
+		{
+			name:     "unchanged",
+			names:    []string{"static", "legacy", "api"},
+			expected: nil,
+		},
+		{
+			name:     "create",
+			names:    []string{"static", "legacy", "api", "admin"},
+			expected: []string{"create ACL 1 admin"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newFakeService(t, clusterConfig{
+				Listeners: []listenerConfig{{Listener: loadbalancer.Listener{ID: 1}, ACLs: existing}},
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceListenerACLs().Schema, testListenerACLsConfig(tc.names...))
+			d.SetId("listener/1")
+
+			if diags := resourceListenerACLsConverge(context.Background(), d, service); diags.HasError() {
+				t.Fatalf("unexpected diagnostics %v", diags)
+			}
+
+			if !reflect.DeepEqual(service.changes, tc.expected) {
+				t.Errorf("expected changes %v, got %v", tc.expected, service.changes)
+			}
+		})
+	}
+}
@@ -0,0 +1,884 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/ptr"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceService() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: autoDeploy(resourceServiceCreate, clusterIDFromClusterID),
+		ReadContext:   resourceServiceRead,
+		UpdateContext: autoDeploy(resourceServiceUpdate, clusterIDFromClusterID),
+		DeleteContext: autoDeploy(resourceServiceDelete, clusterIDFromClusterID),
+		CustomizeDiff: resourceServiceCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"balance": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  loadbalancerservice.TargetGroupBalanceRoundRobin.String(),
+			},
+			"redirect_https": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"monitor_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"listener_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"target_group_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"target": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"weight": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"backup": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"active": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			"bind": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"vip_id": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"port": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+			"certificate": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"certificate": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ca_bundle": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceServiceImport imports a service by the ID of its listener, with the target group
+// taken from the default target group of the listener
+func resourceServiceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	listenerID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("invalid import ID [%s], expected <listener_id>", d.Id())
+	}
+
+	listener, err := service.GetListener(listenerID)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving listener with ID [%d]: %w", listenerID, err)
+	}
+
+	if listener.DefaultTargetGroupID == 0 {
+		return nil, fmt.Errorf("listener with ID [%d] has no default target group", listenerID)
+	}
+
+	return []*schema.ResourceData{d}, d.Set("target_group_id", listener.DefaultTargetGroupID)
+}
+
+// resourceServiceCustomizeDiff rejects targets, binds and certificates which would be matched to
+// the same existing object
+func resourceServiceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for _, blocks := range []struct {
+		key    string
+		fields []string
+		match  string
+		value  func(map[string]interface{}) string
+	}{
+		{key: "target", fields: []string{"ip", "port"}, match: "IP and port", value: serviceTargetKey},
+		{key: "bind", fields: []string{"vip_id", "port"}, match: "VIP and port", value: serviceBindKey},
+		{key: "certificate", fields: []string{"name"}, match: "name", value: serviceCertificateKey},
+	} {
+		seen := make(map[string]bool)
+
+	block:
+		for i, rawBlock := range d.Get(blocks.key).([]interface{}) {
+			for _, field := range blocks.fields {
+				if !d.NewValueKnown(fmt.Sprintf("%s.%d.%s", blocks.key, i, field)) {
+					continue block
+				}
+			}
+
+			value := blocks.value(rawBlock.(map[string]interface{}))
+			if seen[value] {
+				return fmt.Errorf("%s: [%s] is specified more than once, each %s must have a unique %s", blocks.key, value, blocks.key, blocks.match)
+			}
+			seen[value] = true
+		}
+	}
+
+	return nil
+}
+
+// serviceRollback records how to undo each object created while applying a loadbalancer_service,
+// so that a failure part way through doesn't leave partially created objects behind
+type serviceRollback struct {
+	steps []serviceRollbackStep
+}
+
+type serviceRollbackStep struct {
+	description string
+	undo        func() error
+}
+
+func (r *serviceRollback) add(description string, undo func() error) {
+	r.steps = append(r.steps, serviceRollbackStep{description: description, undo: undo})
+}
+
+// run removes recorded objects in the reverse order they were created. Objects which no longer
+// exist are ignored, and objects which can't be removed are returned as errors
+func (r *serviceRollback) run(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for i := len(r.steps) - 1; i >= 0; i-- {
+		step := r.steps[i]
+
+		tflog.Info(ctx, "removing "+step.description)
+
+		err := step.undo()
+		if err != nil && !isServiceObjectNotFound(err) {
+			diags = append(diags, diag.Errorf("Error removing %s: %s", step.description, err)...)
+		}
+	}
+
+	r.steps = nil
+
+	return diags
+}
+
+func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	mode, err := loadbalancerservice.ModeEnum.Parse(d.Get("mode").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	balance, err := loadbalancerservice.TargetGroupBalanceEnum.Parse(d.Get("balance").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rollback := &serviceRollback{}
+
+	targetGroupID, listenerID, err := createServiceObjects(ctx, d, service, rollback, mode, balance)
+	if err != nil {
+		tflog.Info(ctx, "rolling back service", map[string]any{
+			"name":  d.Get("name"),
+			"error": err.Error(),
+		})
+
+		return append(diag.Errorf("Error creating service: %s", err), rollback.run(ctx)...)
+	}
+
+	d.SetId(strconv.Itoa(listenerID))
+	diags := setKeys(d, map[string]any{
+		"listener_id":     listenerID,
+		"target_group_id": targetGroupID,
+	})
+	if diags.HasError() {
+		return diags
+	}
+
+	return resourceServiceRead(ctx, d, meta)
+}
+
+// createServiceObjects creates the target group, targets, listener, binds and certificates of a
+// service in dependency order, recording each against rollback
+func createServiceObjects(ctx context.Context, d *schema.ResourceData, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback,
+	mode loadbalancerservice.Mode, balance loadbalancerservice.TargetGroupBalance) (int, int, error) {
+	name := d.Get("name").(string)
+
+	tflog.Info(ctx, "creating target group", map[string]any{
+		"name":       name,
+		"cluster_id": d.Get("cluster_id"),
+	})
+
+	targetGroupID, err := service.CreateTargetGroup(loadbalancerservice.CreateTargetGroupRequest{
+		ClusterID:  d.Get("cluster_id").(int),
+		Name:       name,
+		Balance:    balance,
+		Mode:       mode,
+		MonitorURL: d.Get("monitor_url").(string),
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error creating target group: %w", err)
+	}
+	rollback.add(fmt.Sprintf("target group with ID [%d]", targetGroupID), func() error {
+		return service.DeleteTargetGroup(targetGroupID)
+	})
+
+	for _, rawTarget := range d.Get("target").([]interface{}) {
+		if err := createServiceTarget(ctx, service, rollback, targetGroupID, rawTarget.(map[string]interface{})); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	tflog.Info(ctx, "creating listener", map[string]any{
+		"name":            name,
+		"target_group_id": targetGroupID,
+	})
+
+	listenerID, err := service.CreateListener(loadbalancerservice.CreateListenerRequest{
+		ClusterID:            d.Get("cluster_id").(int),
+		Name:                 name,
+		Mode:                 mode,
+		RedirectHTTPS:        d.Get("redirect_https").(bool),
+		DefaultTargetGroupID: targetGroupID,
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("error creating listener: %w", err)
+	}
+	rollback.add(fmt.Sprintf("listener with ID [%d]", listenerID), func() error {
+		return service.DeleteListener(listenerID)
+	})
+
+	for _, rawBind := range d.Get("bind").([]interface{}) {
+		if err := createServiceBind(ctx, service, rollback, listenerID, rawBind.(map[string]interface{})); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	for _, rawCertificate := range d.Get("certificate").([]interface{}) {
+		if err := createServiceCertificate(ctx, service, rollback, listenerID, rawCertificate.(map[string]interface{})); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return targetGroupID, listenerID, nil
+}
+
+func createServiceTarget(ctx context.Context, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback, targetGroupID int, target map[string]interface{}) error {
+	tflog.Info(ctx, "creating target", map[string]any{
+		"target_group_id": targetGroupID,
+		"ip":              target["ip"],
+		"port":            target["port"],
+	})
+
+	targetID, err := service.CreateTargetGroupTarget(targetGroupID, loadbalancerservice.CreateTargetRequest{
+		Name:   target["name"].(string),
+		IP:     connection.IPAddress(target["ip"].(string)),
+		Port:   target["port"].(int),
+		Weight: target["weight"].(int),
+		Backup: target["backup"].(bool),
+		Active: target["active"].(bool),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating target [%s]: %w", serviceTargetKey(target), err)
+	}
+
+	rollback.add(fmt.Sprintf("target with ID [%d]", targetID), func() error {
+		return service.DeleteTargetGroupTarget(targetGroupID, targetID)
+	})
+
+	return nil
+}
+
+func createServiceBind(ctx context.Context, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback, listenerID int, bind map[string]interface{}) error {
+	tflog.Info(ctx, "creating bind", map[string]any{
+		"listener_id": listenerID,
+		"vip_id":      bind["vip_id"],
+		"port":        bind["port"],
+	})
+
+	bindID, err := service.CreateListenerBind(listenerID, loadbalancerservice.CreateBindRequest{
+		VIPID: bind["vip_id"].(int),
+		Port:  bind["port"].(int),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating bind [%s]: %w", serviceBindKey(bind), err)
+	}
+
+	rollback.add(fmt.Sprintf("bind with ID [%d]", bindID), func() error {
+		return service.DeleteListenerBind(listenerID, bindID)
+	})
+
+	return nil
+}
+
+func createServiceCertificate(ctx context.Context, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback, listenerID int, certificate map[string]interface{}) error {
+	tflog.Info(ctx, "creating certificate", map[string]any{
+		"listener_id": listenerID,
+		"name":        certificate["name"],
+	})
+
+	certificateID, err := service.CreateListenerCertificate(listenerID, loadbalancerservice.CreateCertificateRequest{
+		Name:        certificate["name"].(string),
+		Key:         certificate["key"].(string),
+		Certificate: certificate["certificate"].(string),
+		CABundle:    certificate["ca_bundle"].(string),
+	})
+	if err != nil {
+		return fmt.Errorf("error creating certificate [%s]: %w", certificate["name"], err)
+	}
+
+	rollback.add(fmt.Sprintf("certificate with ID [%d]", certificateID), func() error {
+		return service.DeleteListenerCertificate(listenerID, certificateID)
+	})
+
+	return nil
+}
+
+func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	listenerID, _ := strconv.Atoi(d.Id())
+	targetGroupID := d.Get("target_group_id").(int)
+
+	tflog.Debug(ctx, "retrieving service", map[string]any{
+		"listener_id":     listenerID,
+		"target_group_id": targetGroupID,
+	})
+
+	listener, err := service.GetListener(listenerID)
+	if err != nil {
+		var listenerNotFoundError *loadbalancerservice.ListenerNotFoundError
+		switch {
+		case errors.As(err, &listenerNotFoundError):
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	targetGroup, err := service.GetTargetGroup(targetGroupID)
+	if err != nil {
+		var targetGroupNotFoundError *loadbalancerservice.TargetGroupNotFoundError
+		switch {
+		case errors.As(err, &targetGroupNotFoundError):
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	targets, err := service.GetTargetGroupTargets(targetGroupID, connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Error retrieving targets for target group with ID [%d]: %s", targetGroupID, err)
+	}
+
+	binds, err := service.GetListenerBinds(listenerID, connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Error retrieving binds for listener with ID [%d]: %s", listenerID, err)
+	}
+
+	certificates, err := service.GetListenerCertificates(listenerID, connection.APIRequestParameters{})
+	if err != nil {
+		return diag.Errorf("Error retrieving certificates for listener with ID [%d]: %s", listenerID, err)
+	}
+
+	var flattenedTargets []map[string]interface{}
+	for _, target := range targets {
+		flattenedTargets = append(flattenedTargets, map[string]interface{}{
+			"id":     target.ID,
+			"name":   target.Name,
+			"ip":     target.IP.String(),
+			"port":   target.Port,
+			"weight": target.Weight,
+			"backup": target.Backup,
+			"active": target.Active,
+		})
+	}
+
+	var flattenedBinds []map[string]interface{}
+	for _, bind := range binds {
+		flattenedBinds = append(flattenedBinds, map[string]interface{}{
+			"id":     bind.ID,
+			"vip_id": bind.VIPID,
+			"port":   bind.Port,
+		})
+	}
+
+	// Key material isn't returned by the API, so is retained from prior state
+	priorCertificates := make(map[int]map[string]interface{})
+	for _, rawCertificate := range d.Get("certificate").([]interface{}) {
+		certificate := rawCertificate.(map[string]interface{})
+		priorCertificates[certificate["id"].(int)] = certificate
+	}
+
+	var flattenedCertificates []map[string]interface{}
+	for _, certificate := range certificates {
+		flattened := map[string]interface{}{
+			"id":   certificate.ID,
+			"name": certificate.Name,
+		}
+
+		if prior, ok := priorCertificates[certificate.ID]; ok {
+			flattened["key"] = prior["key"]
+			flattened["certificate"] = prior["certificate"]
+			flattened["ca_bundle"] = prior["ca_bundle"]
+		}
+
+		flattenedCertificates = append(flattenedCertificates, flattened)
+	}
+
+	return setKeys(d, map[string]any{
+		"cluster_id":      listener.ClusterID,
+		"name":            listener.Name,
+		"mode":            listener.Mode.String(),
+		"balance":         targetGroup.Balance.String(),
+		"redirect_https":  listener.RedirectHTTPS,
+		"monitor_url":     targetGroup.MonitorURL,
+		"listener_id":     listener.ID,
+		"target_group_id": targetGroup.ID,
+		"target":          sortServiceBlocks(flattenedTargets, d.Get("target").([]interface{})),
+		"bind":            sortServiceBlocks(flattenedBinds, d.Get("bind").([]interface{})),
+		"certificate":     sortServiceBlocks(flattenedCertificates, d.Get("certificate").([]interface{})),
+	})
+}
+
+func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	rollback := &serviceRollback{}
+
+	err := updateServiceObjects(ctx, d, service, rollback)
+	if err != nil {
+		// Prior state is retained, with objects changed before the failure reported as drift
+		d.Partial(true)
+
+		return append(diag.Errorf("Error updating service with ID [%s]: %s", d.Id(), err), rollback.run(ctx)...)
+	}
+
+	return resourceServiceRead(ctx, d, meta)
+}
+
+// updateServiceObjects patches the listener and target group of a service, and reconciles its
+// targets, binds and certificates. Objects created are recorded against rollback
+func updateServiceObjects(ctx context.Context, d *schema.ResourceData, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback) error {
+	listenerID := d.Get("listener_id").(int)
+	targetGroupID := d.Get("target_group_id").(int)
+
+	mode, err := loadbalancerservice.ModeEnum.Parse(d.Get("mode").(string))
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("name", "mode", "balance", "monitor_url") {
+		patchReq := loadbalancerservice.PatchTargetGroupRequest{
+			Name:       d.Get("name").(string),
+			Mode:       mode,
+			MonitorURL: d.Get("monitor_url").(string),
+		}
+
+		patchReq.Balance, err = loadbalancerservice.TargetGroupBalanceEnum.Parse(d.Get("balance").(string))
+		if err != nil {
+			return err
+		}
+
+		tflog.Info(ctx, "updating target group", map[string]any{
+			"target_group_id": targetGroupID,
+		})
+
+		err = service.PatchTargetGroup(targetGroupID, patchReq)
+		if err != nil {
+			return fmt.Errorf("error updating target group with ID [%d]: %w", targetGroupID, err)
+		}
+	}
+
+	if d.HasChanges("name", "mode", "redirect_https") {
+		tflog.Info(ctx, "updating listener", map[string]any{
+			"listener_id": listenerID,
+		})
+
+		err = service.PatchListener(listenerID, loadbalancerservice.PatchListenerRequest{
+			Name:          d.Get("name").(string),
+			Mode:          mode,
+			RedirectHTTPS: ptr.Bool(d.Get("redirect_https").(bool)),
+		})
+		if err != nil {
+			return fmt.Errorf("error updating listener with ID [%d]: %w", listenerID, err)
+		}
+	}
+
+	if d.HasChange("target") {
+		if err := updateServiceTargets(ctx, d, service, rollback, targetGroupID); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("bind") {
+		if err := updateServiceBinds(ctx, d, service, rollback, listenerID); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("certificate") {
+		if err := updateServiceCertificates(ctx, d, service, rollback, listenerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateServiceTargets matches configured targets to existing targets by IP and port, as the
+// IDs of list elements shift when targets are added or removed
+func updateServiceTargets(ctx context.Context, d *schema.ResourceData, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback, targetGroupID int) error {
+	rawExisting, rawDesired := d.GetChange("target")
+	existing := serviceBlocksByKey(rawExisting.([]interface{}), serviceTargetKey)
+	desired := serviceBlocksByKey(rawDesired.([]interface{}), serviceTargetKey)
+
+	for _, rawTarget := range rawDesired.([]interface{}) {
+		target := rawTarget.(map[string]interface{})
+
+		current, ok := existing[serviceTargetKey(target)]
+		if !ok {
+			if err := createServiceTarget(ctx, service, rollback, targetGroupID, target); err != nil {
+				return err
+			}
+			continue
+		}
+
+		weight := target["weight"].(int)
+		if target["name"] == current["name"] && target["backup"] == current["backup"] && target["active"] == current["active"] &&
+			(weight == 0 || weight == current["weight"]) {
+			continue
+		}
+
+		targetID := current["id"].(int)
+
+		tflog.Info(ctx, "updating target", map[string]any{
+			"target_id":       targetID,
+			"target_group_id": targetGroupID,
+		})
+
+		err := service.PatchTargetGroupTarget(targetGroupID, targetID, loadbalancerservice.PatchTargetRequest{
+			Name:   target["name"].(string),
+			Weight: weight,
+			Backup: ptr.Bool(target["backup"].(bool)),
+			Active: ptr.Bool(target["active"].(bool)),
+		})
+		if err != nil {
+			return fmt.Errorf("error updating target with ID [%d]: %w", targetID, err)
+		}
+	}
+
+	for key, target := range existing {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		targetID := target["id"].(int)
+
+		tflog.Info(ctx, "removing target", map[string]any{
+			"target_id":       targetID,
+			"target_group_id": targetGroupID,
+		})
+
+		err := service.DeleteTargetGroupTarget(targetGroupID, targetID)
+		if err != nil && !isServiceObjectNotFound(err) {
+			return fmt.Errorf("error removing target with ID [%d]: %w", targetID, err)
+		}
+	}
+
+	return nil
+}
+
+// updateServiceBinds matches configured binds to existing binds by VIP and port
+func updateServiceBinds(ctx context.Context, d *schema.ResourceData, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback, listenerID int) error {
+	rawExisting, rawDesired := d.GetChange("bind")
+	existing := serviceBlocksByKey(rawExisting.([]interface{}), serviceBindKey)
+	desired := serviceBlocksByKey(rawDesired.([]interface{}), serviceBindKey)
+
+	for _, rawBind := range rawDesired.([]interface{}) {
+		bind := rawBind.(map[string]interface{})
+		if _, ok := existing[serviceBindKey(bind)]; ok {
+			continue
+		}
+
+		if err := createServiceBind(ctx, service, rollback, listenerID, bind); err != nil {
+			return err
+		}
+	}
+
+	for key, bind := range existing {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		bindID := bind["id"].(int)
+
+		tflog.Info(ctx, "removing bind", map[string]any{
+			"bind_id":     bindID,
+			"listener_id": listenerID,
+		})
+
+		err := service.DeleteListenerBind(listenerID, bindID)
+		if err != nil && !isServiceObjectNotFound(err) {
+			return fmt.Errorf("error removing bind with ID [%d]: %w", bindID, err)
+		}
+	}
+
+	return nil
+}
+
+// updateServiceCertificates matches configured certificates to existing certificates by name
+func updateServiceCertificates(ctx context.Context, d *schema.ResourceData, service loadbalancerservice.LoadBalancerService, rollback *serviceRollback, listenerID int) error {
+	rawExisting, rawDesired := d.GetChange("certificate")
+	existing := serviceBlocksByKey(rawExisting.([]interface{}), serviceCertificateKey)
+	desired := serviceBlocksByKey(rawDesired.([]interface{}), serviceCertificateKey)
+
+	for _, rawCertificate := range rawDesired.([]interface{}) {
+		certificate := rawCertificate.(map[string]interface{})
+
+		current, ok := existing[serviceCertificateKey(certificate)]
+		if !ok {
+			if err := createServiceCertificate(ctx, service, rollback, listenerID, certificate); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if certificate["key"] == current["key"] && certificate["certificate"] == current["certificate"] && certificate["ca_bundle"] == current["ca_bundle"] {
+			continue
+		}
+
+		certificateID := current["id"].(int)
+
+		tflog.Info(ctx, "updating certificate", map[string]any{
+			"certificate_id": certificateID,
+			"listener_id":    listenerID,
+		})
+
+		err := service.PatchListenerCertificate(listenerID, certificateID, loadbalancerservice.PatchCertificateRequest{
+			Key:         certificate["key"].(string),
+			Certificate: certificate["certificate"].(string),
+			CABundle:    certificate["ca_bundle"].(string),
+		})
+		if err != nil {
+			return fmt.Errorf("error updating certificate with ID [%d]: %w", certificateID, err)
+		}
+	}
+
+	for key, certificate := range existing {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		certificateID := certificate["id"].(int)
+
+		tflog.Info(ctx, "removing certificate", map[string]any{
+			"certificate_id": certificateID,
+			"listener_id":    listenerID,
+		})
+
+		err := service.DeleteListenerCertificate(listenerID, certificateID)
+		if err != nil && !isServiceObjectNotFound(err) {
+			return fmt.Errorf("error removing certificate with ID [%d]: %w", certificateID, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceServiceDelete removes the objects of a service in the reverse order they are created,
+// so that no object is removed while another depends on it
+func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	listenerID := d.Get("listener_id").(int)
+	targetGroupID := d.Get("target_group_id").(int)
+
+	removal := &serviceRollback{}
+
+	removal.add(fmt.Sprintf("target group with ID [%d]", targetGroupID), func() error {
+		return service.DeleteTargetGroup(targetGroupID)
+	})
+
+	for _, rawTarget := range d.Get("target").([]interface{}) {
+		targetID := rawTarget.(map[string]interface{})["id"].(int)
+		removal.add(fmt.Sprintf("target with ID [%d]", targetID), func() error {
+			return service.DeleteTargetGroupTarget(targetGroupID, targetID)
+		})
+	}
+
+	removal.add(fmt.Sprintf("listener with ID [%d]", listenerID), func() error {
+		return service.DeleteListener(listenerID)
+	})
+
+	for _, rawBind := range d.Get("bind").([]interface{}) {
+		bindID := rawBind.(map[string]interface{})["id"].(int)
+		removal.add(fmt.Sprintf("bind with ID [%d]", bindID), func() error {
+			return service.DeleteListenerBind(listenerID, bindID)
+		})
+	}
+
+	for _, rawCertificate := range d.Get("certificate").([]interface{}) {
+		certificateID := rawCertificate.(map[string]interface{})["id"].(int)
+		removal.add(fmt.Sprintf("certificate with ID [%d]", certificateID), func() error {
+			return service.DeleteListenerCertificate(listenerID, certificateID)
+		})
+	}
+
+	return removal.run(ctx)
+}
+
+func serviceTargetKey(target map[string]interface{}) string {
+	return fmt.Sprintf("%s:%d", target["ip"], target["port"])
+}
+
+func serviceBindKey(bind map[string]interface{}) string {
+	return fmt.Sprintf("%d:%d", bind["vip_id"], bind["port"])
+}
+
+func serviceCertificateKey(certificate map[string]interface{}) string {
+	return certificate["name"].(string)
+}
+
+func serviceBlocksByKey(blocks []interface{}, key func(map[string]interface{}) string) map[string]map[string]interface{} {
+	byKey := make(map[string]map[string]interface{})
+	for _, rawBlock := range blocks {
+		block := rawBlock.(map[string]interface{})
+		byKey[key(block)] = block
+	}
+
+	return byKey
+}
+
+// sortServiceBlocks orders blocks to match the IDs of prior blocks. Blocks not previously known,
+// such as those added outside of Terraform, are ordered last by ID so they are reported as drift
+func sortServiceBlocks(blocks []map[string]interface{}, prior []interface{}) []map[string]interface{} {
+	positions := make(map[int]int)
+	for i, rawBlock := range prior {
+		if block, ok := rawBlock.(map[string]interface{}); ok {
+			positions[block["id"].(int)] = i
+		}
+	}
+
+	position := func(block map[string]interface{}) (int, int) {
+		id := block["id"].(int)
+		if i, ok := positions[id]; ok {
+			return i, id
+		}
+		return len(prior), id
+	}
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		iPosition, iID := position(blocks[i])
+		jPosition, jID := position(blocks[j])
+		if iPosition != jPosition {
+			return iPosition < jPosition
+		}
+		return iID < jID
+	})
+
+	return blocks
+}
+
+func isServiceObjectNotFound(err error) bool {
+	var targetGroupNotFoundError *loadbalancerservice.TargetGroupNotFoundError
+	var targetNotFoundError *loadbalancerservice.TargetNotFoundError
+	var listenerNotFoundError *loadbalancerservice.ListenerNotFoundError
+	var bindNotFoundError *loadbalancerservice.BindNotFoundError
+	var certificateNotFoundError *loadbalancerservice.CertificateNotFoundError
+
+	return errors.As(err, &targetGroupNotFoundError) ||
+		errors.As(err, &targetNotFoundError) ||
+		errors.As(err, &listenerNotFoundError) ||
+		errors.As(err, &bindNotFoundError) ||
+		errors.As(err, &certificateNotFoundError)
+}
@@ -0,0 +1,143 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceServiceCreateRollback(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceService().Schema, map[string]interface{}{
+		"cluster_id": 1,
+		"name":       "web",
+		"mode":       "http",
+		"target": []interface{}{
+			map[string]interface{}{"name": "web-1", "ip": "10.0.0.1", "port": 80},
+			map[string]interface{}{"name": "web-2", "ip": "10.0.0.2", "port": 80},
+		},
+		"bind": []interface{}{
+			map[string]interface{}{"vip_id": 1, "port": 443},
+		},
+	})
+
+	service := newFakeService(t, clusterConfig{})
+	service.errs = map[string]error{
+		"CreateListenerBind":      errors.New("port in use"),
+		"DeleteTargetGroupTarget": &loadbalancer.TargetNotFoundError{ID: 3},
+	}
+
+	diags := resourceServiceCreate(context.Background(), d, service)
+	if !diags.HasError() || diags[0].Summary != "Error creating service: error creating bind [1:443]: port in use" {
+		t.Fatalf("expected bind error, got %v", diags)
+	}
+
+	// Targets which have already been removed are ignored
+	if len(diags) != 1 {
+		t.Errorf("expected rollback to succeed, got %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Errorf("expected ID not to be set, got %s", d.Id())
+	}
+
+	expected := []string{
+		"create target group 1",
+		"create target 2 10.0.0.1:80 in target group 1",
+		"create target 3 10.0.0.2:80 in target group 1",
+		"create listener 4 with default target group 1",
+		"delete listener 4",
+		"delete target 3 in target group 1",
+		"delete target 2 in target group 1",
+		"delete target group 1",
+	}
+	if !reflect.DeepEqual(service.changes, expected) {
+		t.Errorf("expected changes %v, got %v", expected, service.changes)
+	}
+}
+
+func TestResourceServiceImport(t *testing.T) {
+	service := newFakeService(t, clusterConfig{
+		Listeners: []listenerConfig{
+			{Listener: loadbalancer.Listener{ID: 4, DefaultTargetGroupID: 1}},
+			{Listener: loadbalancer.Listener{ID: 5}},
+		},
+	})
+
+	for id, expected := range map[string]string{
+		"4":   "",
+		"5":   "listener with ID [5] has no default target group",
+		"web": "invalid import ID [web], expected <listener_id>",
+	} {
+		d := resourceService().TestResourceData()
+		d.SetId(id)
+
+		_, err := resourceServiceImport(context.Background(), d, service)
+		switch {
+		case expected == "" && err != nil:
+			t.Errorf("unexpected error importing [%s]: %s", id, err)
+		case expected == "" && d.Get("target_group_id") != 1:
+			t.Errorf("expected target group 1, got %v", d.Get("target_group_id"))
+		case expected != "" && (err == nil || err.Error() != expected):
+			t.Errorf("expected error [%s] importing [%s], got %v", expected, id, err)
+		}
+	}
+}
+
+func TestResourceServiceDiffDuplicates(t *testing.T) {
+	for name, tc := range map[string]struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		"unique": {
+			raw: map[string]interface{}{
+				"target": []interface{}{
+					map[string]interface{}{"name": "web-1", "ip": "10.0.0.1", "port": 80},
+					map[string]interface{}{"name": "web-2", "ip": "10.0.0.1", "port": 8080},
+				},
+			},
+		},
+		"duplicate target": {
+			raw: map[string]interface{}{
+				"target": []interface{}{
+					map[string]interface{}{"name": "web-1", "ip": "10.0.0.1", "port": 80},
+					map[string]interface{}{"name": "web-2", "ip": "10.0.0.1", "port": 80},
+				},
+			},
+			expected: "target: [10.0.0.1:80] is specified more than once, each target must have a unique IP and port",
+		},
+		"duplicate certificate": {
+			raw: map[string]interface{}{
+				"certificate": []interface{}{
+					map[string]interface{}{"name": "example.com", "key": "a", "certificate": "a"},
+					map[string]interface{}{"name": "example.com", "key": "b", "certificate": "b"},
+				},
+			},
+			expected: "certificate: [example.com] is specified more than once, each certificate must have a unique name",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{
+				"cluster_id": 1,
+				"name":       "web",
+				"mode":       "http",
+				"bind":       []interface{}{map[string]interface{}{"vip_id": 1, "port": 80}},
+			}
+			for key, value := range tc.raw {
+				raw[key] = value
+			}
+
+			_, err := resourceService().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil)
+			switch {
+			case tc.expected == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tc.expected != "" && (err == nil || err.Error() != tc.expected):
+				t.Errorf("expected error [%s], got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -16,10 +16,10 @@ import (
 
 func resourceTarget() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceTargetCreate,
+		CreateContext: autoDeploy(resourceTargetCreate, clusterIDFromTargetGroupID),
 		ReadContext:   resourceTargetRead,
-		UpdateContext: resourceTargetUpdate,
-		DeleteContext: resourceTargetDelete,
+		UpdateContext: autoDeploy(resourceTargetUpdate, clusterIDFromTargetGroupID),
+		DeleteContext: autoDeploy(resourceTargetDelete, clusterIDFromTargetGroupID),
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, i interface{}) ([]*schema.ResourceData, error) {
 				ids := strings.Split(d.Id(), "/")
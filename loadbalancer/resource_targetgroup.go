@@ -14,10 +14,10 @@ import (
 
 func resourceTargetGroup() *schema.Resource {
 	return &schema.Resource{
-		CreateContext: resourceTargetGroupCreate,
+		CreateContext: autoDeploy(resourceTargetGroupCreate, clusterIDFromClusterID),
 		ReadContext:   resourceTargetGroupRead,
-		UpdateContext: resourceTargetGroupUpdate,
-		DeleteContext: resourceTargetGroupDelete,
+		UpdateContext: autoDeploy(resourceTargetGroupUpdate, clusterIDFromClusterID),
+		DeleteContext: autoDeploy(resourceTargetGroupDelete, clusterIDFromClusterID),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -0,0 +1,173 @@
+package loadbalancer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceVip() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: autoDeploy(resourceVipCreate, clusterIDFromClusterID),
+		ReadContext:   resourceVipRead,
+		UpdateContext: autoDeploy(resourceVipUpdate, clusterIDFromClusterID),
+		DeleteContext: autoDeploy(resourceVipDelete, clusterIDFromClusterID),
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"internal", "external"}, false),
+			},
+			"cidr": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"internal_cidr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"external_cidr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mac_address": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(vipManager)
+
+	tflog.Info(ctx, "creating VIP", map[string]any{
+		"cluster_id": d.Get("cluster_id"),
+		"type":       d.Get("type"),
+		"cidr":       d.Get("cidr"),
+	})
+
+	createReq := loadbalancerservice.CreateVIPRequest{
+		ClusterID: d.Get("cluster_id").(int),
+		Type:      d.Get("type").(string),
+		CIDR:      d.Get("cidr").(string),
+	}
+
+	tflog.Debug(ctx, "created CreateVIPRequest", map[string]any{
+		"request": createReq,
+	})
+
+	vip, err := service.CreateVIP(createReq)
+	if err != nil {
+		return diag.Errorf("Error creating VIP: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(vip))
+
+	return resourceVipRead(ctx, d, meta)
+}
+
+func resourceVipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(loadbalancerservice.LoadBalancerService)
+
+	vipID, _ := strconv.Atoi(d.Id())
+
+	tflog.Debug(ctx, "retrieving VIP", map[string]any{
+		"vip_id": vipID,
+	})
+
+	vip, err := service.GetVIP(vipID)
+	if err != nil {
+		var vipNotFoundError *loadbalancerservice.VIPNotFoundError
+		switch {
+		case errors.As(err, &vipNotFoundError):
+			d.SetId("")
+			return nil
+		default:
+			return diag.FromErr(err)
+		}
+	}
+
+	vipType, cidr := flattenVipTypeCIDR(d.Get("type").(string), vip)
+
+	return setKeys(d, map[string]any{
+		"cluster_id":    vip.ClusterID,
+		"type":          vipType,
+		"cidr":          cidr,
+		"internal_cidr": vip.InternalCIDR,
+		"external_cidr": vip.ExternalCIDR,
+		"mac_address":   vip.MACAddress,
+	})
+}
+
+// flattenVipTypeCIDR returns the type and CIDR of a VIP. The API doesn't return the type of a
+// VIP, so the existing type is kept where the VIP has a CIDR of that type, and otherwise a VIP
+// with an external CIDR is external
+func flattenVipTypeCIDR(vipType string, vip loadbalancerservice.VIP) (string, string) {
+	switch {
+	case vipType == "internal" && vip.InternalCIDR != "":
+		return "internal", vip.InternalCIDR
+	case vip.ExternalCIDR != "":
+		return "external", vip.ExternalCIDR
+	default:
+		return "internal", vip.InternalCIDR
+	}
+}
+
+func resourceVipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(vipManager)
+	patchReq := loadbalancerservice.PatchVIPRequest{}
+
+	vipID, _ := strconv.Atoi(d.Id())
+
+	if d.HasChange("type") {
+		patchReq.Type = d.Get("type").(string)
+	}
+
+	if d.HasChange("cidr") {
+		patchReq.CIDR = d.Get("cidr").(string)
+	}
+
+	tflog.Info(ctx, "updating VIP", map[string]any{
+		"vip_id": vipID,
+	})
+
+	err := service.PatchVIP(vipID, patchReq)
+	if err != nil {
+		return diag.Errorf("Error updating VIP with ID [%d]: %s", vipID, err)
+	}
+
+	return resourceVipRead(ctx, d, meta)
+}
+
+func resourceVipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	service := meta.(vipManager)
+
+	vipID, _ := strconv.Atoi(d.Id())
+
+	tflog.Info(ctx, "removing VIP", map[string]any{
+		"vip_id": vipID,
+	})
+
+	err := service.DeleteVIP(vipID)
+	if err != nil {
+		return diag.Errorf("Error removing VIP with ID [%d]: %s", vipID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package loadbalancer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceVipRead(t *testing.T) {
+	for name, tc := range map[string]struct {
+		vipType      string
+		body         string
+		expectedType string
+		expectedCIDR string
+	}{
+		"imported internal": {"", `{"data":{"id":1,"cluster_id":2,"internal_cidr":"10.0.0.5/24"}}`, "internal", "10.0.0.5/24"},
+		"imported external": {"", `{"data":{"id":1,"cluster_id":2,"internal_cidr":"10.0.0.5/24","external_cidr":"203.0.113.10/32"}}`, "external", "203.0.113.10/32"},
+		"internal":          {"internal", `{"data":{"id":1,"cluster_id":2,"internal_cidr":"10.0.0.5/24","external_cidr":"203.0.113.10/32"}}`, "internal", "10.0.0.5/24"},
+		"changed cidr":      {"external", `{"data":{"id":1,"cluster_id":2,"external_cidr":"203.0.113.11/32"}}`, "external", "203.0.113.11/32"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceVip().Schema, map[string]interface{}{
+				"type": tc.vipType,
+				"cidr": "203.0.113.10/32",
+			})
+			d.SetId("1")
+
+			conn := &testConnection{statusCode: 200, body: tc.body}
+
+			diags := resourceVipRead(context.Background(), d, loadbalancer.NewService(conn))
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if d.Get("type") != tc.expectedType || d.Get("cidr") != tc.expectedCIDR || d.Get("cluster_id") != 2 {
+				t.Errorf("expected type [%s] and cidr [%s], got [%s] and [%s]", tc.expectedType, tc.expectedCIDR, d.Get("type"), d.Get("cidr"))
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	loadbalancerservice "github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+// clusterManager provides the cluster provisioning calls which are not yet available on the
+// SDK's LoadBalancerService
+type clusterManager interface {
+	CreateCluster(req createClusterRequest) (int, error)
+	DeleteCluster(clusterID int) error
+}
+
+// createClusterRequest represents a request to create a cluster. The API takes only the name of
+// the cluster, with deployments triggered separately by the loadbalancer_cluster_deployment
+// resource or auto_deploy, so the deployment attributes of loadbalancer_cluster are computed only
+type createClusterRequest struct {
+	Name string `json:"name"`
+}
+
+// vipManager provides the VIP management calls which are not yet available on the SDK's
+// LoadBalancerService
+type vipManager interface {
+	CreateVIP(req loadbalancerservice.CreateVIPRequest) (int, error)
+	PatchVIP(vipID int, req loadbalancerservice.PatchVIPRequest) error
+	DeleteVIP(vipID int) error
+}
+
+// aclManager provides the ACL calls which are not yet available on the SDK's LoadBalancerService
+type aclManager interface {
+	GetACLWithPriority(aclID int) (aclWithPriority, error)
+	GetACLsWithPriority(parameters connection.APIRequestParameters) ([]aclWithPriority, error)
+}
+
+// clusterValidator validates the staged configuration of a cluster, returning the errors and
+// warnings which the SDK's ValidateCluster discards
+type clusterValidator interface {
+	validateCluster(clusterID int) (clusterValidation, error)
+}
+
+// clusterValidation is the result of validating the staged configuration of a cluster
+type clusterValidation struct {
+	Valid    bool
+	Errors   []string
+	Warnings []string
+}
+
+// clusterValidationResponseBody is the body returned by the cluster validate endpoint. A failed
+// validation is reported as an error per problem found, and any warnings are returned as data
+type clusterValidationResponseBody struct {
+	connection.APIResponseBody
+
+	Data struct {
+		Warnings []string `json:"warnings"`
+	} `json:"data"`
+}
+
+// aclWithPriority is an ACL along with its priority, which the SDK's ACL model omits
+type aclWithPriority struct {
+	loadbalancerservice.ACL
+
+	Priority int `json:"priority"`
+}
+
+// providerService extends the SDK's LoadBalancerService with calls which the SDK does not yet
+// provide, and is used as the provider meta
+type providerService struct {
+	loadbalancerservice.LoadBalancerService
+
+	connection connection.Connection
+}
+
+func newProviderService(conn connection.Connection, service loadbalancerservice.LoadBalancerService) *providerService {
+	return &providerService{
+		LoadBalancerService: service,
+		connection:          conn,
+	}
+}
+
+// CreateCluster creates a cluster
+func (s *providerService) CreateCluster(req createClusterRequest) (int, error) {
+	body, err := connection.Post[loadbalancerservice.Cluster](s.connection, "/loadbalancers/v2/clusters", &req)
+
+	return body.Data.ID, err
+}
+
+// DeleteCluster deletes a cluster
+func (s *providerService) DeleteCluster(clusterID int) error {
+	if clusterID < 1 {
+		return fmt.Errorf("invalid cluster id")
+	}
+
+	_, err := connection.Delete[interface{}](s.connection, fmt.Sprintf("/loadbalancers/v2/clusters/%d", clusterID), nil, connection.NotFoundResponseHandler(&loadbalancerservice.ClusterNotFoundError{ID: clusterID}))
+
+	return err
+}
+
+// CreateVIP creates a VIP
+func (s *providerService) CreateVIP(req loadbalancerservice.CreateVIPRequest) (int, error) {
+	body, err := connection.Post[loadbalancerservice.VIP](s.connection, "/loadbalancers/v2/vips", &req)
+
+	return body.Data.ID, err
+}
+
+// PatchVIP patches a VIP
+func (s *providerService) PatchVIP(vipID int, req loadbalancerservice.PatchVIPRequest) error {
+	if vipID < 1 {
+		return fmt.Errorf("invalid vip id")
+	}
+
+	_, err := connection.Patch[interface{}](s.connection, fmt.Sprintf("/loadbalancers/v2/vips/%d", vipID), &req, connection.NotFoundResponseHandler(&loadbalancerservice.VIPNotFoundError{ID: vipID}))
+
+	return err
+}
+
+// DeleteVIP deletes a VIP
+func (s *providerService) DeleteVIP(vipID int) error {
+	if vipID < 1 {
+		return fmt.Errorf("invalid vip id")
+	}
+
+	_, err := connection.Delete[interface{}](s.connection, fmt.Sprintf("/loadbalancers/v2/vips/%d", vipID), nil, connection.NotFoundResponseHandler(&loadbalancerservice.VIPNotFoundError{ID: vipID}))
+
+	return err
+}
+
+// validateCluster validates the staged configuration of a cluster. A failed validation is
+// returned by the API with a 422 status, and isn't an error
+func (s *providerService) validateCluster(clusterID int) (clusterValidation, error) {
+	if clusterID < 1 {
+		return clusterValidation{}, fmt.Errorf("invalid cluster id")
+	}
+
+	response, err := s.connection.Get(fmt.Sprintf("/loadbalancers/v2/clusters/%d/validate", clusterID), connection.APIRequestParameters{})
+	if err != nil {
+		return clusterValidation{}, err
+	}
+
+	body := &clusterValidationResponseBody{}
+
+	if response.StatusCode != http.StatusUnprocessableEntity {
+		err := response.HandleResponse(body, connection.NotFoundResponseHandler(&loadbalancerservice.ClusterNotFoundError{ID: clusterID}))
+		if err != nil {
+			return clusterValidation{}, err
+		}
+
+		return clusterValidation{Valid: true, Warnings: body.Data.Warnings}, nil
+	}
+
+	err = connection.APIResponseJSONDeserializer(response, body)
+	if err != nil {
+		return clusterValidation{}, err
+	}
+
+	validation := clusterValidation{Warnings: body.Data.Warnings}
+	for _, item := range body.Errors {
+		if item.Detail != "" {
+			validation.Errors = append(validation.Errors, item.Detail)
+		} else {
+			validation.Errors = append(validation.Errors, item.Title)
+		}
+	}
+
+	if len(validation.Errors) == 0 {
+		message := body.Message
+		if message == "" {
+			message = "staged configuration failed validation"
+		}
+
+		validation.Errors = []string{message}
+	}
+
+	return validation, nil
+}
+
+// GetACLWithPriority retrieves a single ACL, including its priority
+func (s *providerService) GetACLWithPriority(aclID int) (aclWithPriority, error) {
+	if aclID < 1 {
+		return aclWithPriority{}, fmt.Errorf("invalid acl id")
+	}
+
+	body, err := connection.Get[aclWithPriority](s.connection, fmt.Sprintf("/loadbalancers/v2/acls/%d", aclID), connection.APIRequestParameters{}, connection.NotFoundResponseHandler(&loadbalancerservice.ACLNotFoundError{ID: aclID}))
+
+	return body.Data, err
+}
+
+// GetACLsWithPriority retrieves a list of ACLs, including their priority. As with the SDK's
+// GetACLs, a listener_id or target_group_id filter must be provided
+func (s *providerService) GetACLsWithPriority(parameters connection.APIRequestParameters) ([]aclWithPriority, error) {
+	return connection.InvokeRequestAll(s.getACLsWithPriorityPaginated, parameters)
+}
+
+func (s *providerService) getACLsWithPriorityPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[aclWithPriority], error) {
+	body := &connection.APIResponseBodyData[[]aclWithPriority]{}
+	err := connection.GetRaw(s.connection, "/loadbalancers/v2/acls", parameters, body)
+
+	return connection.NewPaginated(body, parameters, s.getACLsWithPriorityPaginated), err
+}
@@ -0,0 +1,323 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+// failingConnection fails the test on any request, so calls to methods fakeService doesn't
+// implement are reported rather than reaching the API
+type failingConnection struct {
+	connection.Connection
+
+	t *testing.T
+}
+
+func (c *failingConnection) fail(method string, resource string) (*connection.APIResponse, error) {
+	c.t.Helper()
+	c.t.Fatalf("unexpected request %s %s, the method calling it isn't implemented by fakeService", method, resource)
+	return nil, nil
+}
+
+func (c *failingConnection) Get(resource string, parameters connection.APIRequestParameters) (*connection.APIResponse, error) {
+	return c.fail("GET", resource)
+}
+
+func (c *failingConnection) Post(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.fail("POST", resource)
+}
+
+func (c *failingConnection) Put(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.fail("PUT", resource)
+}
+
+func (c *failingConnection) Patch(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.fail("PATCH", resource)
+}
+
+func (c *failingConnection) Delete(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.fail("DELETE", resource)
+}
+
+// fakeService serves the objects of config and the deployments of clusters, recording the changes made and the requests to
+// create objects, which are given sequential IDs following nextID. Methods named in errs return
+// the error instead, and calls to methods which aren't implemented fail the test
+type fakeService struct {
+	*providerService
+
+	config      clusterConfig
+	deployments []loadbalancer.Deployment
+	nextID      int
+	errs        map[string]error
+
+	changes      []string
+	targetGroups []loadbalancer.CreateTargetGroupRequest
+	listeners    []loadbalancer.CreateListenerRequest
+	binds        []loadbalancer.CreateBindRequest
+	acls         []loadbalancer.CreateACLRequest
+}
+
+func newFakeService(t *testing.T, config clusterConfig) *fakeService {
+	conn := &failingConnection{t: t}
+
+	return &fakeService{
+		providerService: newProviderService(conn, loadbalancer.NewService(conn)),
+		config:          config,
+	}
+}
+
+func (s *fakeService) id() int {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *fakeService) listener(listenerID int) listenerConfig {
+	for _, listener := range s.config.Listeners {
+		if listener.ID == listenerID {
+			return listener
+		}
+	}
+	return listenerConfig{}
+}
+
+func (s *fakeService) GetCluster(clusterID int) (loadbalancer.Cluster, error) {
+	return s.config.Cluster, nil
+}
+
+func (s *fakeService) GetVIPs(parameters connection.APIRequestParameters) ([]loadbalancer.VIP, error) {
+	return s.config.VIPs, nil
+}
+
+func (s *fakeService) GetListeners(parameters connection.APIRequestParameters) ([]loadbalancer.Listener, error) {
+	var listeners []loadbalancer.Listener
+	for _, listener := range s.config.Listeners {
+		listeners = append(listeners, listener.Listener)
+	}
+	return listeners, nil
+}
+
+func (s *fakeService) GetTargetGroups(parameters connection.APIRequestParameters) ([]loadbalancer.TargetGroup, error) {
+	var targetGroups []loadbalancer.TargetGroup
+	for _, targetGroup := range s.config.TargetGroups {
+		targetGroups = append(targetGroups, targetGroup.TargetGroup)
+	}
+	return targetGroups, nil
+}
+
+func (s *fakeService) GetListener(listenerID int) (loadbalancer.Listener, error) {
+	for _, listener := range s.config.Listeners {
+		if listener.ID == listenerID {
+			return listener.Listener, nil
+		}
+	}
+	return loadbalancer.Listener{}, &loadbalancer.ListenerNotFoundError{ID: listenerID}
+}
+
+func (s *fakeService) GetTargetGroup(targetGroupID int) (loadbalancer.TargetGroup, error) {
+	for _, targetGroup := range s.config.TargetGroups {
+		if targetGroup.ID == targetGroupID {
+			return targetGroup.TargetGroup, nil
+		}
+	}
+	return loadbalancer.TargetGroup{}, &loadbalancer.TargetGroupNotFoundError{ID: targetGroupID}
+}
+
+func (s *fakeService) GetListenerBinds(listenerID int, parameters connection.APIRequestParameters) ([]loadbalancer.Bind, error) {
+	return s.listener(listenerID).Binds, nil
+}
+
+func (s *fakeService) GetListenerCertificates(listenerID int, parameters connection.APIRequestParameters) ([]loadbalancer.Certificate, error) {
+	return s.listener(listenerID).Certificates, nil
+}
+
+func (s *fakeService) GetListenerCertificate(listenerID int, certificateID int) (loadbalancer.Certificate, error) {
+	for _, certificate := range s.listener(listenerID).Certificates {
+		if certificate.ID == certificateID {
+			return certificate, nil
+		}
+	}
+	return loadbalancer.Certificate{}, &loadbalancer.CertificateNotFoundError{ID: certificateID}
+}
+
+func (s *fakeService) GetListenerAccessIPs(listenerID int, parameters connection.APIRequestParameters) ([]loadbalancer.AccessIP, error) {
+	return s.listener(listenerID).AccessIPs, nil
+}
+
+func (s *fakeService) GetTargetGroupTargets(targetGroupID int, parameters connection.APIRequestParameters) ([]loadbalancer.Target, error) {
+	for _, targetGroup := range s.config.TargetGroups {
+		if targetGroup.ID == targetGroupID {
+			return targetGroup.Targets, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *fakeService) GetACLWithPriority(aclID int) (aclWithPriority, error) {
+	var acls []aclWithPriority
+	for _, listener := range s.config.Listeners {
+		acls = append(acls, listener.ACLs...)
+	}
+	for _, targetGroup := range s.config.TargetGroups {
+		acls = append(acls, targetGroup.ACLs...)
+	}
+
+	for _, acl := range acls {
+		if acl.ID == aclID {
+			return acl, nil
+		}
+	}
+	return aclWithPriority{}, &loadbalancer.ACLNotFoundError{ID: aclID}
+}
+
+func (s *fakeService) GetACLsWithPriority(parameters connection.APIRequestParameters) ([]aclWithPriority, error) {
+	filter := parameters.Filtering[0]
+	id, _ := strconv.Atoi(filter.Value[0])
+
+	if filter.Property == "listener_id" {
+		return s.listener(id).ACLs, nil
+	}
+
+	for _, targetGroup := range s.config.TargetGroups {
+		if targetGroup.ID == id {
+			return targetGroup.ACLs, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetDeploymentsPaginated returns the deployments of the filtered cluster, most recent first
+func (s *fakeService) GetDeploymentsPaginated(parameters connection.APIRequestParameters) (*connection.Paginated[loadbalancer.Deployment], error) {
+	if err := s.errs["GetDeploymentsPaginated"]; err != nil {
+		return nil, err
+	}
+
+	clusterID, _ := strconv.Atoi(parameters.Filtering[0].Value[0])
+
+	var deployments []loadbalancer.Deployment
+	for i := len(s.deployments) - 1; i >= 0; i-- {
+		if s.deployments[i].ClusterID == clusterID {
+			deployments = append(deployments, s.deployments[i])
+		}
+	}
+
+	body := &connection.APIResponseBodyData[[]loadbalancer.Deployment]{Data: deployments}
+	return connection.NewPaginated(body, parameters, s.GetDeploymentsPaginated), nil
+}
+
+func (s *fakeService) DeployCluster(clusterID int) error {
+	s.changes = append(s.changes, fmt.Sprintf("deploy cluster %d", clusterID))
+	return s.errs["DeployCluster"]
+}
+
+func (s *fakeService) CreateTargetGroup(req loadbalancer.CreateTargetGroupRequest) (int, error) {
+	if err := s.errs["CreateTargetGroup"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.targetGroups = append(s.targetGroups, req)
+	s.changes = append(s.changes, fmt.Sprintf("create target group %d", id))
+	return id, nil
+}
+
+func (s *fakeService) CreateTargetGroupTarget(targetGroupID int, req loadbalancer.CreateTargetRequest) (int, error) {
+	if err := s.errs["CreateTargetGroupTarget"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.changes = append(s.changes, fmt.Sprintf("create target %d %s:%d in target group %d", id, req.IP, req.Port, targetGroupID))
+	return id, nil
+}
+
+func (s *fakeService) CreateListener(req loadbalancer.CreateListenerRequest) (int, error) {
+	if err := s.errs["CreateListener"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.listeners = append(s.listeners, req)
+	s.changes = append(s.changes, fmt.Sprintf("create listener %d with default target group %d", id, req.DefaultTargetGroupID))
+	return id, nil
+}
+
+func (s *fakeService) CreateListenerBind(listenerID int, req loadbalancer.CreateBindRequest) (int, error) {
+	if err := s.errs["CreateListenerBind"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.binds = append(s.binds, req)
+	s.changes = append(s.changes, fmt.Sprintf("create bind %d %d:%d in listener %d", id, req.VIPID, req.Port, listenerID))
+	return id, nil
+}
+
+func (s *fakeService) CreateListenerCertificate(listenerID int, req loadbalancer.CreateCertificateRequest) (int, error) {
+	if err := s.errs["CreateListenerCertificate"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.changes = append(s.changes, fmt.Sprintf("create certificate %d %s in listener %d", id, req.Name, listenerID))
+	return id, nil
+}
+
+func (s *fakeService) CreateListenerAccessIP(listenerID int, req loadbalancer.CreateAccessIPRequest) (int, error) {
+	if err := s.errs["CreateListenerAccessIP"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.changes = append(s.changes, fmt.Sprintf("create access IP %d %s in listener %d", id, req.IP, listenerID))
+	return id, nil
+}
+
+func (s *fakeService) CreateACL(req loadbalancer.CreateACLRequest) (int, error) {
+	if err := s.errs["CreateACL"]; err != nil {
+		return 0, err
+	}
+
+	id := s.id()
+	s.acls = append(s.acls, req)
+	s.changes = append(s.changes, fmt.Sprintf("create ACL %d %s", id, req.Name))
+	return id, nil
+}
+
+func (s *fakeService) PatchTargetGroup(targetGroupID int, req loadbalancer.PatchTargetGroupRequest) error {
+	s.changes = append(s.changes, fmt.Sprintf("patch target group %d balance %s", targetGroupID, req.Balance))
+	return s.errs["PatchTargetGroup"]
+}
+
+func (s *fakeService) DeleteTargetGroup(targetGroupID int) error {
+	s.changes = append(s.changes, fmt.Sprintf("delete target group %d", targetGroupID))
+	return s.errs["DeleteTargetGroup"]
+}
+
+func (s *fakeService) DeleteTargetGroupTarget(targetGroupID int, targetID int) error {
+	s.changes = append(s.changes, fmt.Sprintf("delete target %d in target group %d", targetID, targetGroupID))
+	return s.errs["DeleteTargetGroupTarget"]
+}
+
+func (s *fakeService) DeleteListener(listenerID int) error {
+	s.changes = append(s.changes, fmt.Sprintf("delete listener %d", listenerID))
+	return s.errs["DeleteListener"]
+}
+
+func (s *fakeService) DeleteListenerCertificate(listenerID int, certificateID int) error {
+	s.changes = append(s.changes, fmt.Sprintf("delete certificate %d in listener %d", certificateID, listenerID))
+	return s.errs["DeleteListenerCertificate"]
+}
+
+func (s *fakeService) PatchACL(aclID int, req loadbalancer.PatchACLRequest) error {
+	s.changes = append(s.changes, fmt.Sprintf("patch ACL %d %s priority %d", aclID, req.Name, req.Priority))
+	return s.errs["PatchACL"]
+}
+
+func (s *fakeService) DeleteACL(aclID int) error {
+	s.changes = append(s.changes, fmt.Sprintf("delete ACL %d", aclID))
+	return s.errs["DeleteACL"]
+}
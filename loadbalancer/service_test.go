@@ -0,0 +1,161 @@
+package loadbalancer
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+)
+
+// testConnection responds to requests with a fixed status code and body, unless a body is given
+// for the method and resource, recording the requests and their bodies
+type testConnection struct {
+	connection.Connection
+
+	statusCode int
+	body       string
+	bodies     map[string]string
+
+	requests      []string
+	requestBodies []string
+}
+
+func (c *testConnection) respond(method string, resource string, body interface{}) (*connection.APIResponse, error) {
+	c.requests = append(c.requests, method+" "+resource)
+
+	if body != nil {
+		requestBody, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+
+		c.requestBodies = append(c.requestBodies, string(requestBody))
+	}
+
+	responseBody, ok := c.bodies[method+" "+resource]
+	if !ok {
+		responseBody = c.body
+	}
+
+	return &connection.APIResponse{
+		Response: &http.Response{
+			StatusCode: c.statusCode,
+			Body:       io.NopCloser(strings.NewReader(responseBody)),
+		},
+	}, nil
+}
+
+func (c *testConnection) Get(resource string, parameters connection.APIRequestParameters) (*connection.APIResponse, error) {
+	return c.respond("GET", resource, nil)
+}
+
+func (c *testConnection) Post(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.respond("POST", resource, body)
+}
+
+func (c *testConnection) Patch(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.respond("PATCH", resource, body)
+}
+
+func (c *testConnection) Delete(resource string, body interface{}) (*connection.APIResponse, error) {
+	return c.respond("DELETE", resource, body)
+}
+
+func TestProviderServiceCreateCluster(t *testing.T) {
+	conn := &testConnection{statusCode: 201, body: `{"data":{"id":5}}`}
+
+	clusterID, err := newProviderService(conn, nil).CreateCluster(createClusterRequest{Name: "cluster-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if clusterID != 5 || !reflect.DeepEqual(conn.requests, []string{"POST /loadbalancers/v2/clusters"}) {
+		t.Errorf("unexpected cluster ID %d or requests %v", clusterID, conn.requests)
+	}
+}
+
+func TestProviderServiceValidateCluster(t *testing.T) {
+	for name, tc := range map[string]struct {
+		statusCode int
+		body       string
+		expected   clusterValidation
+		err        error
+	}{
+		"valid": {
+			statusCode: 200,
+			body:       `{"data":{"warnings":["listener [web] has no binds"]}}`,
+			expected:   clusterValidation{Valid: true, Warnings: []string{"listener [web] has no binds"}},
+		},
+		"invalid": {
+			statusCode: 422,
+			body:       `{"errors":[{"title":"Validation Error","detail":"bind port 443 is in use","status":422},{"title":"Missing certificate","status":422}],"data":{"warnings":["target group [web] has no targets"]}}`,
+			expected: clusterValidation{
+				Errors:   []string{"bind port 443 is in use", "Missing certificate"},
+				Warnings: []string{"target group [web] has no targets"},
+			},
+		},
+		"invalid without detail": {
+			statusCode: 422,
+			body:       `{}`,
+			expected:   clusterValidation{Errors: []string{"staged configuration failed validation"}},
+		},
+		"not found": {
+			statusCode: 404,
+			body:       `{}`,
+			err:        &loadbalancer.ClusterNotFoundError{ID: 1},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			conn := &testConnection{statusCode: tc.statusCode, body: tc.body}
+
+			validation, err := newProviderService(conn, nil).validateCluster(1)
+			switch {
+			case tc.err != nil && (err == nil || !errors.As(err, new(*loadbalancer.ClusterNotFoundError))):
+				t.Errorf("expected error [%s], got %v", tc.err, err)
+			case tc.err == nil && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case !reflect.DeepEqual(validation, tc.expected):
+				t.Errorf("expected %+v, got %+v", tc.expected, validation)
+			}
+
+			if !reflect.DeepEqual(conn.requests, []string{"GET /loadbalancers/v2/clusters/1/validate"}) {
+				t.Errorf("unexpected requests %v", conn.requests)
+			}
+		})
+	}
+}
+
+func TestProviderServiceGetACLsWithPriority(t *testing.T) {
+	conn := &testConnection{
+		statusCode: 200,
+		body:       `{"data":[{"id":10,"name":"static","listener_id":1,"priority":2},{"id":11,"name":"legacy","listener_id":1,"priority":1}],"meta":{"pagination":{"total":2,"count":2,"per_page":100,"total_pages":1}}}`,
+		bodies: map[string]string{
+			"GET /loadbalancers/v2/acls/10": `{"data":{"id":10,"name":"static","listener_id":1,"priority":2}}`,
+		},
+	}
+	service := newProviderService(conn, nil)
+
+	acl, err := service.GetACLWithPriority(10)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if acl.ID != 10 || acl.Name != "static" || acl.Priority != 2 {
+		t.Errorf("unexpected ACL %+v", acl)
+	}
+
+	acls, err := getACLsWithPriority(service, "listener_id", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(acls) != 2 || acls[0].Priority != 2 || acls[1].Priority != 1 {
+		t.Errorf("unexpected ACLs %+v", acls)
+	}
+}
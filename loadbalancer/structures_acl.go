@@ -1,22 +1,42 @@
 package loadbalancer
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+var aclArgumentResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"value": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+}
+
 func expandACLConditions(rawConditions []interface{}) []loadbalancer.ACLCondition {
 	var conditions []loadbalancer.ACLCondition
 	for _, rawCondition := range rawConditions {
 		condition := rawCondition.(map[string]interface{})
 
-		arguments := make(map[string]loadbalancer.ACLArgument)
-		for _, argument := range expandACLArguments(condition["argument"].(*schema.Set).List()) {
-			arguments[argument.Name] = argument
-		}
+		inverted, _ := condition["inverted"].(bool)
+		name, arguments := expandACLRule(condition, aclConditionBlocks)
 
 		conditions = append(conditions, loadbalancer.ACLCondition{
-			Name:      condition["name"].(string),
+			Name:      name,
+			Inverted:  inverted,
 			Arguments: arguments,
 		})
 	}
@@ -25,13 +45,13 @@ func expandACLConditions(rawConditions []interface{}) []loadbalancer.ACLConditio
 }
 
 func flattenACLConditions(conditions []loadbalancer.ACLCondition) []map[string]interface{} {
-	var flattenedConditions []map[string]interface{}
+	flattenedConditions := make([]map[string]interface{}, 0, len(conditions))
 	for _, condition := range conditions {
-		flattenedCondition := make(map[string]interface{})
-		flattenedCondition["name"] = condition.Name
-		flattenedCondition["arguments"] = flattenACLArguments(condition.Arguments)
-
-		flattenedConditions = append(flattenedConditions, flattenedCondition)
+		flattenedConditions = append(flattenedConditions, map[string]interface{}{
+			"name":     condition.Name,
+			"inverted": condition.Inverted,
+			"argument": flattenACLArguments(condition.Arguments),
+		})
 	}
 
 	return flattenedConditions
@@ -42,13 +62,10 @@ func expandACLActions(rawActions []interface{}) []loadbalancer.ACLAction {
 	for _, rawAction := range rawActions {
 		action := rawAction.(map[string]interface{})
 
-		arguments := make(map[string]loadbalancer.ACLArgument)
-		for _, argument := range expandACLArguments(action["argument"].(*schema.Set).List()) {
-			arguments[argument.Name] = argument
-		}
+		name, arguments := expandACLRule(action, aclActionBlocks)
 
 		actions = append(actions, loadbalancer.ACLAction{
-			Name:      action["name"].(string),
+			Name:      name,
 			Arguments: arguments,
 		})
 	}
@@ -57,47 +74,274 @@ func expandACLActions(rawActions []interface{}) []loadbalancer.ACLAction {
 }
 
 func flattenACLActions(actions []loadbalancer.ACLAction) []map[string]interface{} {
-	var flattenedActions []map[string]interface{}
+	flattenedActions := make([]map[string]interface{}, 0, len(actions))
 	for _, action := range actions {
-		flattenedAction := make(map[string]interface{})
-		flattenedAction["name"] = action.Name
-		flattenedAction["arguments"] = flattenACLArguments(action.Arguments)
-
-		flattenedActions = append(flattenedActions, flattenedAction)
+		flattenedActions = append(flattenedActions, map[string]interface{}{
+			"name":     action.Name,
+			"argument": flattenACLArguments(action.Arguments),
+		})
 	}
 
 	return flattenedActions
 }
 
-func expandACLArguments(rawArguments []interface{}) []loadbalancer.ACLArgument {
-	var arguments []loadbalancer.ACLArgument
+// expandACLRule returns the name and arguments of a condition or action, from either its typed
+// block or the generic name/argument form
+func expandACLRule(rule map[string]interface{}, blocks []aclBlock) (string, map[string]loadbalancer.ACLArgument) {
+	if name, arguments, ok := expandACLBlock(rule, blocks); ok {
+		return name, arguments
+	}
+
+	var rawArguments []interface{}
+	if argumentSet, ok := rule["argument"].(*schema.Set); ok {
+		rawArguments = argumentSet.List()
+	}
+
+	name, _ := rule["name"].(string)
+
+	return name, expandACLArguments(rawArguments)
+}
+
+func expandACLArguments(rawArguments []interface{}) map[string]loadbalancer.ACLArgument {
+	arguments := make(map[string]loadbalancer.ACLArgument)
 	for _, rawArgument := range rawArguments {
 		argument := rawArgument.(map[string]interface{})
 
-		arguments = append(arguments, loadbalancer.ACLArgument{
+		arguments[argument["name"].(string)] = loadbalancer.ACLArgument{
 			Name:  argument["name"].(string),
 			Value: argument["value"].(string),
-		})
+		}
 	}
 
 	return arguments
 }
 
-func flattenACLArguments(arguments map[string]loadbalancer.ACLArgument) []map[string]interface{} {
-	var flattenedArguments []map[string]interface{}
-	for _, argument := range arguments {
-		flattenedArguments = append(flattenedArguments, map[string]interface{}{
-			"name":  argument.Name,
-			"value": argument.Value,
+func flattenACLArguments(arguments map[string]loadbalancer.ACLArgument) *schema.Set {
+	flattenedArguments := schema.NewSet(schema.HashResource(aclArgumentResource), nil)
+	for name, argument := range arguments {
+		// The argument name is also the key of the map returned by the API, and may be omitted
+		// from the argument itself
+		if argument.Name != "" {
+			name = argument.Name
+		}
+
+		flattenedArguments.Add(map[string]interface{}{
+			"name":  name,
+			"value": flattenACLArgumentValue(argument.Value),
 		})
 	}
 
 	return flattenedArguments
 }
 
-func flattenACLArgument(argument loadbalancer.ACLArgument) (string, map[string]interface{}) {
-	arguments := make(map[string]interface{})
-	arguments["name"] = argument.Name
-	arguments["value"] = argument.Value
-	return argument.Name, arguments
+// flattenACLArgumentValue returns the string representation of an argument value. Values are
+// sent to the API as strings, however may be returned as other JSON types, which are encoded
+// as JSON
+func flattenACLArgumentValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(encoded)
+}
+
+// validateACLTemplates ensures each condition and action, and each of their arguments, exists
+// within templates. Names which are not yet known are skipped. Errors are reported against keys
+// beginning with prefix
+func validateACLTemplates(prefix string, templates loadbalancer.ACLTemplates, conditions []loadbalancer.ACLCondition, actions []loadbalancer.ACLAction) error {
+	conditionTemplates := make(map[string][]loadbalancer.ACLTemplateArgument)
+	for _, template := range templates.Conditions {
+		conditionTemplates[template.Name] = template.Arguments
+	}
+
+	actionTemplates := make(map[string][]loadbalancer.ACLTemplateArgument)
+	for _, template := range templates.Actions {
+		actionTemplates[template.Name] = template.Arguments
+	}
+
+	var errs []error
+	for i, condition := range conditions {
+		errs = append(errs, validateACLTemplate(fmt.Sprintf("%scondition.%d", prefix, i), "condition", condition.Name, condition.Arguments, conditionTemplates)...)
+	}
+
+	for i, action := range actions {
+		errs = append(errs, validateACLTemplate(fmt.Sprintf("%saction.%d", prefix, i), "action", action.Name, action.Arguments, actionTemplates)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateACLTemplate(key string, kind string, name string, arguments map[string]loadbalancer.ACLArgument, templates map[string][]loadbalancer.ACLTemplateArgument) []error {
+	if name == "" {
+		return nil
+	}
+
+	templateArguments, ok := templates[name]
+	if !ok {
+		return []error{fmt.Errorf("%s: unknown %s %q, expected one of: %s", key, kind, name, strings.Join(sortedKeys(templates), ", "))}
+	}
+
+	validArguments := make(map[string]loadbalancer.ACLTemplateArgument)
+	for _, templateArgument := range templateArguments {
+		validArguments[templateArgument.Name] = templateArgument
+	}
+
+	var errs []error
+	for _, argumentName := range sortedKeys(arguments) {
+		if argumentName == "" {
+			continue
+		}
+
+		templateArgument, ok := validArguments[argumentName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: unknown argument %q for %s %q, expected one of: %s", key, argumentName, kind, name, strings.Join(sortedKeys(validArguments), ", ")))
+			continue
+		}
+
+		value, ok := arguments[argumentName].Value.(string)
+		if ok && value != "" && len(templateArgument.Values) > 0 && !slices.Contains(templateArgument.Values, value) {
+			errs = append(errs, fmt.Errorf("%s: invalid value %q for argument %q of %s %q, expected one of: %s", key, value, argumentName, kind, name, strings.Join(templateArgument.Values, ", ")))
+		}
+	}
+
+	return errs
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// matchACLs pairs each desired ACL with an existing ACL, first by name where the name is unique
+// to both, then by position. Existing ACLs which aren't paired are returned as unmatched
+func matchACLs(desired []aclWithPriority, existing []aclWithPriority) ([]*aclWithPriority, []aclWithPriority) {
+	countNames := func(acls []aclWithPriority) map[string]int {
+		counts := make(map[string]int)
+		for _, acl := range acls {
+			counts[acl.Name]++
+		}
+		return counts
+	}
+
+	desiredNames := countNames(desired)
+	existingNames := countNames(existing)
+
+	matches := make([]*aclWithPriority, len(desired))
+	matched := make([]bool, len(existing))
+
+	for i, acl := range desired {
+		if acl.Name == "" || desiredNames[acl.Name] != 1 || existingNames[acl.Name] != 1 {
+			continue
+		}
+
+		for j := range existing {
+			if existing[j].Name == acl.Name {
+				matches[i] = &existing[j]
+				matched[j] = true
+				break
+			}
+		}
+	}
+
+	j := 0
+	for i := range desired {
+		if matches[i] != nil {
+			continue
+		}
+
+		for j < len(existing) && matched[j] {
+			j++
+		}
+
+		if j < len(existing) {
+			matches[i] = &existing[j]
+			matched[j] = true
+			j++
+		}
+	}
+
+	var unmatched []aclWithPriority
+	for j, acl := range existing {
+		if !matched[j] {
+			unmatched = append(unmatched, acl)
+		}
+	}
+
+	return matches, unmatched
+}
+
+// aclsEqual returns whether the name, priority, conditions and actions of a and b are equal
+func aclsEqual(a aclWithPriority, b aclWithPriority) bool {
+	return a.Name == b.Name &&
+		a.Priority == b.Priority &&
+		aclRulesEqual(flattenACLConditions(a.Conditions), flattenACLConditions(b.Conditions)) &&
+		aclRulesEqual(flattenACLActions(a.Actions), flattenACLActions(b.Actions))
+}
+
+func aclRulesEqual(a []map[string]interface{}, b []map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i]["name"] != b[i]["name"] || a[i]["inverted"] != b[i]["inverted"] {
+			return false
+		}
+
+		if !a[i]["argument"].(*schema.Set).Equal(b[i]["argument"]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortACLsByPriority sorts ACLs into the order they're evaluated, by priority and then by ID.
+// ACLs which are yet to be created are placed after existing ACLs of the same priority
+func sortACLsByPriority(acls []aclWithPriority) {
+	sort.SliceStable(acls, func(i, j int) bool {
+		if acls[i].Priority != acls[j].Priority {
+			return acls[i].Priority < acls[j].Priority
+		}
+
+		return acls[i].ID != 0 && (acls[j].ID == 0 || acls[i].ID < acls[j].ID)
+	})
+}
+
+// aclEvaluationOrder returns the names of ACLs in the order they're evaluated. ACLs without a
+// name are listed by ID, or as new where yet to be created
+func aclEvaluationOrder(acls []aclWithPriority) []string {
+	sorted := append([]aclWithPriority(nil), acls...)
+	sortACLsByPriority(sorted)
+
+	order := make([]string, 0, len(sorted))
+	for _, acl := range sorted {
+		switch {
+		case acl.Name != "":
+			order = append(order, acl.Name)
+		case acl.ID != 0:
+			order = append(order, fmt.Sprintf("acl %d", acl.ID))
+		default:
+			order = append(order, "new acl")
+		}
+	}
+
+	return order
 }
@@ -0,0 +1,295 @@
+package loadbalancer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aclPathRegexp = regexp.MustCompile(`^/`)
+
+// aclBlockField is a typed field of an ACL block, which maps to the ACL argument of the same name
+type aclBlockField struct {
+	name         string
+	valueType    schema.ValueType
+	required     bool
+	description  string
+	validateFunc schema.SchemaValidateFunc
+}
+
+// aclBlock is a typed alternative to the generic name/argument form of an ACL condition or
+// action. The block name is the name of the condition or action
+type aclBlock struct {
+	name   string
+	fields []aclBlockField
+}
+
+var aclConditionBlocks = []aclBlock{
+	{
+		name: "header_matches",
+		fields: []aclBlockField{
+			{name: "header", valueType: schema.TypeString, required: true, description: "Name of header", validateFunc: validation.StringIsNotWhiteSpace},
+			{name: "value", valueType: schema.TypeString, required: true, description: "Value of header"},
+		},
+	},
+	{
+		name: "path_begins_with",
+		fields: []aclBlockField{
+			{name: "path", valueType: schema.TypeString, required: true, description: "Path prefix", validateFunc: validation.StringMatch(aclPathRegexp, "must begin with /")},
+		},
+	},
+	{
+		name: "source_ip",
+		fields: []aclBlockField{
+			{name: "ip", valueType: schema.TypeList, required: true, description: "List of source IP addresses or CIDR ranges", validateFunc: validation.Any(validation.IsIPAddress, validation.IsCIDR)},
+		},
+	},
+}
+
+var aclActionBlocks = []aclBlock{
+	{
+		name: "redirect",
+		fields: []aclBlockField{
+			{name: "location", valueType: schema.TypeString, required: true, description: "Redirect location", validateFunc: validation.StringIsNotWhiteSpace},
+			{name: "status", valueType: schema.TypeInt, description: "HTTP status code of redirect", validateFunc: validation.IntInSlice([]int{301, 302, 303, 307, 308})},
+		},
+	},
+	{
+		name: "use_target_group",
+		fields: []aclBlockField{
+			{name: "target_group_id", valueType: schema.TypeInt, required: true, description: "ID of target group", validateFunc: validation.IntAtLeast(1)},
+		},
+	},
+	{
+		name: "set_header",
+		fields: []aclBlockField{
+			{name: "header", valueType: schema.TypeString, required: true, description: "Name of header", validateFunc: validation.StringIsNotWhiteSpace},
+			{name: "value", valueType: schema.TypeString, required: true, description: "Value of header"},
+		},
+	},
+}
+
+// aclBlockSchema adds a schema for each of blocks to s
+func aclBlockSchema(s map[string]*schema.Schema, blocks []aclBlock) map[string]*schema.Schema {
+	for _, block := range blocks {
+		fields := make(map[string]*schema.Schema)
+		for _, field := range block.fields {
+			fieldSchema := &schema.Schema{
+				Type:        field.valueType,
+				Required:    field.required,
+				Optional:    !field.required,
+				Description: field.description,
+			}
+
+			if field.valueType == schema.TypeList {
+				fieldSchema.MinItems = 1
+				fieldSchema.Elem = &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: field.validateFunc,
+				}
+			} else {
+				fieldSchema.ValidateFunc = field.validateFunc
+			}
+
+			fields[field.name] = fieldSchema
+		}
+
+		s[block.name] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: fields,
+			},
+		}
+	}
+
+	return s
+}
+
+// aclBlockNames returns the names of blocks
+func aclBlockNames(blocks []aclBlock) []string {
+	var names []string
+	for _, block := range blocks {
+		names = append(names, block.name)
+	}
+
+	return names
+}
+
+// expandACLBlock returns the name and arguments of the typed block set within rawRule, if any
+func expandACLBlock(rawRule map[string]interface{}, blocks []aclBlock) (string, map[string]loadbalancer.ACLArgument, bool) {
+	for _, block := range blocks {
+		rawBlocks, _ := rawRule[block.name].([]interface{})
+		if len(rawBlocks) < 1 || rawBlocks[0] == nil {
+			continue
+		}
+
+		rawBlock := rawBlocks[0].(map[string]interface{})
+
+		arguments := make(map[string]loadbalancer.ACLArgument)
+		for _, field := range block.fields {
+			value, ok := expandACLBlockFieldValue(field, rawBlock[field.name])
+			if !ok {
+				continue
+			}
+
+			arguments[field.name] = loadbalancer.ACLArgument{
+				Name:  field.name,
+				Value: value,
+			}
+		}
+
+		return block.name, arguments, true
+	}
+
+	return "", nil, false
+}
+
+// expandACLBlockFieldValue returns the API value of a field, or false if an optional field is unset
+func expandACLBlockFieldValue(field aclBlockField, rawValue interface{}) (interface{}, bool) {
+	switch field.valueType {
+	case schema.TypeInt:
+		value, _ := rawValue.(int)
+		return value, field.required || value != 0
+	case schema.TypeBool:
+		value, _ := rawValue.(bool)
+		return value, field.required || value
+	case schema.TypeList:
+		rawValues, _ := rawValue.([]interface{})
+		values := make([]string, 0, len(rawValues))
+		for _, v := range rawValues {
+			values = append(values, v.(string))
+		}
+		return values, field.required || len(values) > 0
+	default:
+		value, _ := rawValue.(string)
+		return value, field.required || value != ""
+	}
+}
+
+// flattenACLBlocks converts each flattened condition or action into its typed block where one
+// is available. Elements which were configured using the generic name/argument form in prior
+// are left as is, so either form may be used without producing a diff
+func flattenACLBlocks(flattened []map[string]interface{}, prior []interface{}, blocks []aclBlock) []map[string]interface{} {
+	for i, rule := range flattened {
+		if i < len(prior) {
+			if priorRule, ok := prior[i].(map[string]interface{}); ok && priorRule["name"] != "" {
+				continue
+			}
+		}
+
+		for _, block := range blocks {
+			if block.name != rule["name"] {
+				continue
+			}
+
+			flattenedBlock, ok := flattenACLBlock(block, rule["argument"].(*schema.Set))
+			if !ok {
+				break
+			}
+
+			rule["name"] = ""
+			rule["argument"] = schema.NewSet(schema.HashResource(aclArgumentResource), nil)
+			rule[block.name] = []interface{}{flattenedBlock}
+			break
+		}
+	}
+
+	return flattened
+}
+
+// flattenACLBlock returns the fields of block from arguments, or false if arguments cannot be
+// represented by block
+func flattenACLBlock(block aclBlock, arguments *schema.Set) (map[string]interface{}, bool) {
+	values := make(map[string]string)
+	for _, rawArgument := range arguments.List() {
+		argument := rawArgument.(map[string]interface{})
+		values[argument["name"].(string)] = argument["value"].(string)
+	}
+
+	flattenedBlock := make(map[string]interface{})
+	for _, field := range block.fields {
+		value, ok := values[field.name]
+		if !ok {
+			if field.required {
+				return nil, false
+			}
+			continue
+		}
+		delete(values, field.name)
+
+		flattenedValue, err := flattenACLBlockFieldValue(field, value)
+		if err != nil {
+			return nil, false
+		}
+
+		flattenedBlock[field.name] = flattenedValue
+	}
+
+	return flattenedBlock, len(values) == 0
+}
+
+// flattenACLBlockFieldValue parses the string representation of an argument value, as returned
+// by flattenACLArgumentValue, into the type of field
+func flattenACLBlockFieldValue(field aclBlockField, value string) (interface{}, error) {
+	switch field.valueType {
+	case schema.TypeInt:
+		return strconv.Atoi(value)
+	case schema.TypeBool:
+		return strconv.ParseBool(value)
+	case schema.TypeList:
+		var values []string
+		if strings.HasPrefix(value, "[") {
+			if err := json.Unmarshal([]byte(value), &values); err != nil {
+				return nil, err
+			}
+		} else {
+			for _, v := range strings.Split(value, ",") {
+				values = append(values, strings.TrimSpace(v))
+			}
+		}
+
+		flattenedValues := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			flattenedValues = append(flattenedValues, v)
+		}
+		return flattenedValues, nil
+	default:
+		return value, nil
+	}
+}
+
+// validateACLBlocks ensures each of the conditions or actions within key sets exactly one of
+// name or a typed block. Elements with an unknown name are skipped until it is known
+func validateACLBlocks(d *schema.ResourceDiff, key string, blocks []aclBlock) error {
+	for i, rawRule := range d.Get(key).([]interface{}) {
+		rule, ok := rawRule.(map[string]interface{})
+		if !ok || !d.NewValueKnown(fmt.Sprintf("%s.%d.name", key, i)) {
+			continue
+		}
+
+		set := 0
+		if name, _ := rule["name"].(string); name != "" {
+			set++
+		}
+
+		for _, block := range blocks {
+			if rawBlocks, _ := rule[block.name].([]interface{}); len(rawBlocks) > 0 {
+				set++
+			}
+		}
+
+		if set != 1 {
+			return fmt.Errorf("%s.%d: exactly one of name, %s must be specified", key, i, strings.Join(aclBlockNames(blocks), ", "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,548 @@
+package loadbalancer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ans-group/sdk-go/pkg/service/loadbalancer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testACLArgumentSet(arguments ...map[string]interface{}) *schema.Set {
+	set := schema.NewSet(schema.HashResource(aclArgumentResource), nil)
+	for _, argument := range arguments {
+		set.Add(argument)
+	}
+
+	return set
+}
+
+func TestExpandFlattenACLConditions(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      []interface{}
+		expanded []loadbalancer.ACLCondition
+	}{
+		{
+			name:     "empty",
+			raw:      []interface{}{},
+			expanded: nil,
+		},
+		{
+			name: "single condition with arguments",
+			raw: []interface{}{
+				map[string]interface{}{
+					"name":     "header_matches",
+					"inverted": false,
+					"argument": testACLArgumentSet(
+						map[string]interface{}{"name": "header", "value": "host"},
+						map[string]interface{}{"name": "value", "value": "ukfast.co.uk"},
+					),
+				},
+			},
+			expanded: []loadbalancer.ACLCondition{
+				{
+					Name: "header_matches",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"header": {Name: "header", Value: "host"},
+						"value":  {Name: "value", Value: "ukfast.co.uk"},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple conditions retain order and inversion",
+			raw: []interface{}{
+				map[string]interface{}{
+					"name":     "path_begins_with",
+					"inverted": true,
+					"argument": testACLArgumentSet(
+						map[string]interface{}{"name": "path", "value": "/static"},
+					),
+				},
+				map[string]interface{}{
+					"name":     "source_ip",
+					"inverted": false,
+					"argument": testACLArgumentSet(
+						map[string]interface{}{"name": "ip", "value": "10.0.0.0/8"},
+					),
+				},
+			},
+			expanded: []loadbalancer.ACLCondition{
+				{
+					Name:     "path_begins_with",
+					Inverted: true,
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"path": {Name: "path", Value: "/static"},
+					},
+				},
+				{
+					Name: "source_ip",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"ip": {Name: "ip", Value: "10.0.0.0/8"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := expandACLConditions(tt.raw)
+			if !reflect.DeepEqual(expanded, tt.expanded) {
+				t.Fatalf("expandACLConditions: expected %#v, got %#v", tt.expanded, expanded)
+			}
+
+			flattened := flattenACLConditions(expanded)
+			if len(flattened) != len(tt.raw) {
+				t.Fatalf("flattenACLConditions: expected %d conditions, got %d", len(tt.raw), len(flattened))
+			}
+
+			for i, rawCondition := range tt.raw {
+				condition := rawCondition.(map[string]interface{})
+				if flattened[i]["name"] != condition["name"] || flattened[i]["inverted"] != condition["inverted"] {
+					t.Errorf("flattenACLConditions: condition %d: expected %#v, got %#v", i, condition, flattened[i])
+				}
+
+				if !condition["argument"].(*schema.Set).Equal(flattened[i]["argument"]) {
+					t.Errorf("flattenACLConditions: condition %d: expected arguments %v, got %v", i, condition["argument"].(*schema.Set).List(), flattened[i]["argument"].(*schema.Set).List())
+				}
+			}
+		})
+	}
+}
+
+func TestExpandFlattenACLActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      []interface{}
+		expanded []loadbalancer.ACLAction
+	}{
+		{
+			name:     "empty",
+			raw:      []interface{}{},
+			expanded: nil,
+		},
+		{
+			name: "redirect",
+			raw: []interface{}{
+				map[string]interface{}{
+					"name": "redirect",
+					"argument": testACLArgumentSet(
+						map[string]interface{}{"name": "location", "value": "developers.ukfast.io"},
+						map[string]interface{}{"name": "status", "value": "302"},
+					),
+				},
+			},
+			expanded: []loadbalancer.ACLAction{
+				{
+					Name: "redirect",
+					Arguments: map[string]loadbalancer.ACLArgument{
+						"location": {Name: "location", Value: "developers.ukfast.io"},
+						"status":   {Name: "status", Value: "302"},
+					},
+				},
+			},
+		},
+		{
+			name: "action without arguments",
+			raw: []interface{}{
+				map[string]interface{}{
+					"name":     "deny",
+					"argument": testACLArgumentSet(),
+				},
+			},
+			expanded: []loadbalancer.ACLAction{
+				{
+					Name:      "deny",
+					Arguments: map[string]loadbalancer.ACLArgument{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expanded := expandACLActions(tt.raw)
+			if !reflect.DeepEqual(expanded, tt.expanded) {
+				t.Fatalf("expandACLActions: expected %#v, got %#v", tt.expanded, expanded)
+			}
+
+			flattened := flattenACLActions(expanded)
+			if len(flattened) != len(tt.raw) {
+				t.Fatalf("flattenACLActions: expected %d actions, got %d", len(tt.raw), len(flattened))
+			}
+
+			for i, rawAction := range tt.raw {
+				action := rawAction.(map[string]interface{})
+				if flattened[i]["name"] != action["name"] {
+					t.Errorf("flattenACLActions: action %d: expected name %q, got %q", i, action["name"], flattened[i]["name"])
+				}
+
+				if !action["argument"].(*schema.Set).Equal(flattened[i]["argument"]) {
+					t.Errorf("flattenACLActions: action %d: expected arguments %v, got %v", i, action["argument"].(*schema.Set).List(), flattened[i]["argument"].(*schema.Set).List())
+				}
+			}
+		})
+	}
+}
+
+func TestFlattenACLArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments map[string]loadbalancer.ACLArgument
+		expected  *schema.Set
+	}{
+		{
+			name: "argument name omitted uses key",
+			arguments: map[string]loadbalancer.ACLArgument{
+				"header": {Value: "host"},
+			},
+			expected: testACLArgumentSet(
+				map[string]interface{}{"name": "header", "value": "host"},
+			),
+		},
+		{
+			name: "non-string values",
+			arguments: map[string]loadbalancer.ACLArgument{
+				"status": {Name: "status", Value: float64(302)},
+				"secure": {Name: "secure", Value: true},
+				"ips":    {Name: "ips", Value: []interface{}{"1.2.3.4", "5.6.7.8"}},
+				"empty":  {Name: "empty", Value: nil},
+			},
+			expected: testACLArgumentSet(
+				map[string]interface{}{"name": "status", "value": "302"},
+				map[string]interface{}{"name": "secure", "value": "true"},
+				map[string]interface{}{"name": "ips", "value": `["1.2.3.4","5.6.7.8"]`},
+				map[string]interface{}{"name": "empty", "value": ""},
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flattened := flattenACLArguments(tt.arguments)
+			if !tt.expected.Equal(flattened) {
+				t.Errorf("expected %v, got %v", tt.expected.List(), flattened.List())
+			}
+		})
+	}
+}
+
+func TestResourceACLReadRoundTrip(t *testing.T) {
+	acl := loadbalancer.ACL{
+		Conditions: []loadbalancer.ACLCondition{
+			{
+				Name: "header_matches",
+				Arguments: map[string]loadbalancer.ACLArgument{
+					"header": {Name: "header", Value: "host"},
+					"value":  {Name: "value", Value: "ukfast.co.uk"},
+				},
+			},
+		},
+		Actions: []loadbalancer.ACLAction{
+			{
+				Name: "redirect",
+				Arguments: map[string]loadbalancer.ACLArgument{
+					"location": {Name: "location", Value: "developers.ukfast.io"},
+					"status":   {Name: "status", Value: "302"},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceACL().Schema, map[string]interface{}{})
+	diags := setKeys(d, map[string]any{
+		"condition": flattenACLConditions(acl.Conditions),
+		"action":    flattenACLActions(acl.Actions),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected error setting state: %v", diags)
+	}
+
+	conditions := expandACLConditions(d.Get("condition").([]interface{}))
+	if !reflect.DeepEqual(conditions, acl.Conditions) {
+		t.Errorf("conditions: expected %#v, got %#v", acl.Conditions, conditions)
+	}
+
+	actions := expandACLActions(d.Get("action").([]interface{}))
+	if !reflect.DeepEqual(actions, acl.Actions) {
+		t.Errorf("actions: expected %#v, got %#v", acl.Actions, actions)
+	}
+}
+
+func TestExpandFlattenACLBlocks(t *testing.T) {
+	raw := map[string]interface{}{
+		"listener_id": 1,
+		"condition": []interface{}{
+			map[string]interface{}{
+				"header_matches": []interface{}{
+					map[string]interface{}{"header": "host", "value": "ukfast.co.uk"},
+				},
+			},
+			map[string]interface{}{
+				"inverted": true,
+				"source_ip": []interface{}{
+					map[string]interface{}{"ip": []interface{}{"10.0.0.0/8", "1.2.3.4"}},
+				},
+			},
+			map[string]interface{}{
+				"name": "path_begins_with",
+				"argument": []interface{}{
+					map[string]interface{}{"name": "path", "value": "/static"},
+				},
+			},
+		},
+		"action": []interface{}{
+			map[string]interface{}{
+				"redirect": []interface{}{
+					map[string]interface{}{"location": "developers.ukfast.io", "status": 302},
+				},
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceACL().Schema, raw)
+
+	conditions := expandACLConditions(d.Get("condition").([]interface{}))
+	expectedConditions := []loadbalancer.ACLCondition{
+		{
+			Name: "header_matches",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"header": {Name: "header", Value: "host"},
+				"value":  {Name: "value", Value: "ukfast.co.uk"},
+			},
+		},
+		{
+			Name:     "source_ip",
+			Inverted: true,
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"ip": {Name: "ip", Value: []string{"10.0.0.0/8", "1.2.3.4"}},
+			},
+		},
+		{
+			Name: "path_begins_with",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"path": {Name: "path", Value: "/static"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(conditions, expectedConditions) {
+		t.Fatalf("conditions: expected %#v, got %#v", expectedConditions, conditions)
+	}
+
+	actions := expandACLActions(d.Get("action").([]interface{}))
+	expectedActions := []loadbalancer.ACLAction{
+		{
+			Name: "redirect",
+			Arguments: map[string]loadbalancer.ACLArgument{
+				"location": {Name: "location", Value: "developers.ukfast.io"},
+				"status":   {Name: "status", Value: 302},
+			},
+		},
+	}
+	if !reflect.DeepEqual(actions, expectedActions) {
+		t.Fatalf("actions: expected %#v, got %#v", expectedActions, actions)
+	}
+
+	// The API returns numbers as JSON numbers and lists as JSON arrays
+	conditions[1].Arguments["ip"] = loadbalancer.ACLArgument{Name: "ip", Value: []interface{}{"10.0.0.0/8", "1.2.3.4"}}
+	actions[0].Arguments["status"] = loadbalancer.ACLArgument{Name: "status", Value: float64(302)}
+
+	t.Run("retains configured form", func(t *testing.T) {
+		state := schema.TestResourceDataRaw(t, resourceACL().Schema, raw)
+		diags := setKeys(state, map[string]any{
+			"condition": flattenACLBlocks(flattenACLConditions(conditions), state.Get("condition").([]interface{}), aclConditionBlocks),
+			"action":    flattenACLBlocks(flattenACLActions(actions), state.Get("action").([]interface{}), aclActionBlocks),
+		})
+		if diags.HasError() {
+			t.Fatalf("unexpected error setting state: %v", diags)
+		}
+
+		if !reflect.DeepEqual(expandACLConditions(state.Get("condition").([]interface{})), expectedConditions) {
+			t.Errorf("conditions: expected %#v, got %#v", expectedConditions, expandACLConditions(state.Get("condition").([]interface{})))
+		}
+
+		if !reflect.DeepEqual(expandACLActions(state.Get("action").([]interface{})), expectedActions) {
+			t.Errorf("actions: expected %#v, got %#v", expectedActions, expandACLActions(state.Get("action").([]interface{})))
+		}
+
+		for _, key := range []string{"condition.0.name", "condition.1.name", "condition.2.name", "action.0.name"} {
+			if state.Get(key) != d.Get(key) {
+				t.Errorf("%s: expected %q, got %q", key, d.Get(key), state.Get(key))
+			}
+		}
+	})
+
+	t.Run("import prefers typed blocks", func(t *testing.T) {
+		flattened := flattenACLBlocks(flattenACLConditions(conditions), nil, aclConditionBlocks)
+		if flattened[2]["name"] != "" || len(flattened[2]["path_begins_with"].([]interface{})) != 1 {
+			t.Errorf("expected path_begins_with block, got %#v", flattened[2])
+		}
+	})
+
+	t.Run("unrepresentable arguments use generic form", func(t *testing.T) {
+		flattened := flattenACLBlocks(flattenACLActions([]loadbalancer.ACLAction{
+			{
+				Name: "redirect",
+				Arguments: map[string]loadbalancer.ACLArgument{
+					"location": {Name: "location", Value: "developers.ukfast.io"},
+					"unknown":  {Name: "unknown", Value: "value"},
+				},
+			},
+		}), nil, aclActionBlocks)
+		if flattened[0]["name"] != "redirect" {
+			t.Errorf("expected generic redirect action, got %#v", flattened[0])
+		}
+	})
+}
+
+func TestValidateACLTemplates(t *testing.T) {
+	templates := loadbalancer.ACLTemplates{
+		Conditions: []loadbalancer.ACLTemplateCondition{
+			{
+				Name: "header_matches",
+				Arguments: []loadbalancer.ACLTemplateArgument{
+					{Name: "header"},
+					{Name: "value"},
+				},
+			},
+		},
+		Actions: []loadbalancer.ACLTemplateAction{
+			{
+				Name: "redirect",
+				Arguments: []loadbalancer.ACLTemplateArgument{
+					{Name: "location"},
+					{Name: "status", Values: []string{"301", "302"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		conditions []loadbalancer.ACLCondition
+		actions    []loadbalancer.ACLAction
+		valid      bool
+	}{
+		{
+			name: "valid",
+			conditions: []loadbalancer.ACLCondition{
+				{Name: "header_matches", Arguments: map[string]loadbalancer.ACLArgument{"header": {Value: "host"}, "value": {Value: "ukfast.co.uk"}}},
+			},
+			actions: []loadbalancer.ACLAction{
+				{Name: "redirect", Arguments: map[string]loadbalancer.ACLArgument{"location": {Value: "developers.ukfast.io"}, "status": {Value: "302"}}},
+			},
+			valid: true,
+		},
+		{
+			name: "unknown names skipped",
+			conditions: []loadbalancer.ACLCondition{
+				{Name: "", Arguments: map[string]loadbalancer.ACLArgument{"anything": {}}},
+			},
+			actions: []loadbalancer.ACLAction{
+				{Name: "redirect", Arguments: map[string]loadbalancer.ACLArgument{"": {}}},
+			},
+			valid: true,
+		},
+		{
+			name: "unknown condition",
+			conditions: []loadbalancer.ACLCondition{
+				{Name: "header_matchs"},
+			},
+		},
+		{
+			name: "unknown action argument",
+			actions: []loadbalancer.ACLAction{
+				{Name: "redirect", Arguments: map[string]loadbalancer.ACLArgument{"locaton": {Value: "developers.ukfast.io"}}},
+			},
+		},
+		{
+			name: "value not permitted",
+			actions: []loadbalancer.ACLAction{
+				{Name: "redirect", Arguments: map[string]loadbalancer.ACLArgument{"status": {Value: "418"}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateACLTemplates("", templates, tt.conditions, tt.actions)
+			if tt.valid && err != nil {
+				t.Errorf("expected no error, got %s", err)
+			}
+
+			if !tt.valid && err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestMatchACLs(t *testing.T) {
+	acl := func(id int, name string) aclWithPriority {
+		return aclWithPriority{ACL: loadbalancer.ACL{ID: id, Name: name}}
+	}
+
+	tests := []struct {
+		name      string
+		desired   []aclWithPriority
+		existing  []aclWithPriority
+		matches   []int
+		unmatched []int
+	}{
+		{
+			name:     "by position",
+			desired:  []aclWithPriority{acl(0, ""), acl(0, "")},
+			existing: []aclWithPriority{acl(1, ""), acl(2, "")},
+			matches:  []int{1, 2},
+		},
+		{
+			name:     "insert in middle matches by name",
+			desired:  []aclWithPriority{acl(0, "a"), acl(0, "new"), acl(0, "b")},
+			existing: []aclWithPriority{acl(1, "a"), acl(2, "b")},
+			matches:  []int{1, 0, 2},
+		},
+		{
+			name:      "removed and unmanaged",
+			desired:   []aclWithPriority{acl(0, "b")},
+			existing:  []aclWithPriority{acl(1, "a"), acl(2, "b"), acl(3, "")},
+			matches:   []int{2},
+			unmatched: []int{1, 3},
+		},
+		{
+			name:     "duplicate names match by position",
+			desired:  []aclWithPriority{acl(0, "a"), acl(0, "a")},
+			existing: []aclWithPriority{acl(1, "a"), acl(2, "a")},
+			matches:  []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, unmatched := matchACLs(tt.desired, tt.existing)
+
+			var matchIDs []int
+			for _, match := range matches {
+				if match == nil {
+					matchIDs = append(matchIDs, 0)
+					continue
+				}
+				matchIDs = append(matchIDs, match.ID)
+			}
+
+			var unmatchedIDs []int
+			for _, acl := range unmatched {
+				unmatchedIDs = append(unmatchedIDs, acl.ID)
+			}
+
+			if !reflect.DeepEqual(matchIDs, tt.matches) {
+				t.Errorf("matches: expected %v, got %v", tt.matches, matchIDs)
+			}
+
+			if !reflect.DeepEqual(unmatchedIDs, tt.unmatched) {
+				t.Errorf("unmatched: expected %v, got %v", tt.unmatched, unmatchedIDs)
+			}
+		})
+	}
+}
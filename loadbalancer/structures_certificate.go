@@ -0,0 +1,257 @@
+package loadbalancer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ans-group/sdk-go/pkg/connection"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// parseCertificatesPEM returns the certificates within PEM encoded data, ignoring other blocks
+func parseCertificatesPEM(data string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return certificates, nil
+}
+
+// parseCertificatePEM returns the first certificate within PEM encoded data
+func parseCertificatePEM(data string) (*x509.Certificate, error) {
+	certificates, err := parseCertificatesPEM(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return certificates[0], nil
+}
+
+// formatHexBytes formats bytes as colon separated upper case hex, as output by openssl
+func formatHexBytes(b []byte) string {
+	parts := make([]string, len(b))
+	for i := range b {
+		parts[i] = fmt.Sprintf("%02X", b[i])
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// flattenCertificateDetails returns the attributes of the PEM encoded certificate and CA bundle,
+// which are empty where they can't be parsed
+func flattenCertificateDetails(certificatePEM string, caBundlePEM string) map[string]any {
+	details := map[string]any{
+		"fingerprint_sha256":  "",
+		"serial_number":       "",
+		"not_before":          "",
+		"not_after":           "",
+		"subject":             "",
+		"issuer":              "",
+		"dns_names":           []string{},
+		"ip_addresses":        []string{},
+		"days_until_expiry":   0,
+		"ca_bundle_not_after": "",
+	}
+
+	if certificate, err := parseCertificatePEM(certificatePEM); err == nil {
+		fingerprint := sha256.Sum256(certificate.Raw)
+
+		var ipAddresses []string
+		for _, ip := range certificate.IPAddresses {
+			ipAddresses = append(ipAddresses, ip.String())
+		}
+
+		details["fingerprint_sha256"] = formatHexBytes(fingerprint[:])
+		details["serial_number"] = formatHexBytes(certificate.SerialNumber.Bytes())
+		details["not_before"] = certificate.NotBefore.UTC().Format(time.RFC3339)
+		details["not_after"] = certificate.NotAfter.UTC().Format(time.RFC3339)
+		details["subject"] = certificate.Subject.String()
+		details["issuer"] = certificate.Issuer.String()
+		details["dns_names"] = certificate.DNSNames
+		details["ip_addresses"] = ipAddresses
+		details["days_until_expiry"] = daysUntil(certificate.NotAfter)
+	}
+
+	// The CA bundle expires with the earliest expiring certificate within it
+	if caBundle, err := parseCertificatesPEM(caBundlePEM); err == nil {
+		notAfter := caBundle[0].NotAfter
+		for _, certificate := range caBundle[1:] {
+			if certificate.NotAfter.Before(notAfter) {
+				notAfter = certificate.NotAfter
+			}
+		}
+
+		details["ca_bundle_not_after"] = notAfter.UTC().Format(time.RFC3339)
+	}
+
+	return details
+}
+
+// daysUntil returns the number of whole days until t, which is negative once t has passed
+func daysUntil(t time.Time) int {
+	return int(math.Floor(time.Until(t).Hours() / 24))
+}
+
+// parsePrivateKeyPEM returns the first private key within PEM encoded data. Encrypted keys and
+// keys other than RSA and ECDSA keys aren't supported by loadbalancers, so are rejected
+func parsePrivateKeyPEM(data string) (crypto.Signer, error) {
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no PEM encoded private key found")
+		}
+
+		if block.Type == "ENCRYPTED PRIVATE KEY" || block.Headers["Proc-Type"] == "4,ENCRYPTED" {
+			return nil, errors.New("private key is encrypted, the unencrypted key must be provided")
+		}
+
+		var key interface{}
+		var err error
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			key, err = x509.ParseECPrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", strings.ToLower(block.Type), err)
+		}
+
+		switch key := key.(type) {
+		case *rsa.PrivateKey:
+			return key, nil
+		case *ecdsa.PrivateKey:
+			return key, nil
+		default:
+			return nil, fmt.Errorf("unsupported private key type %T, expected an RSA or ECDSA key", key)
+		}
+	}
+}
+
+// validateCertificateKeyPair returns an error where the PEM encoded private key isn't the key of
+// the PEM encoded certificate
+func validateCertificateKeyPair(certificatePEM string, keyPEM string) error {
+	certificate, err := parseCertificatePEM(certificatePEM)
+	if err != nil {
+		return fmt.Errorf("certificate: %w", err)
+	}
+
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+
+	publicKey, ok := certificate.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(key.Public()) {
+		return fmt.Errorf("key: private key doesn't match the public key of certificate [%s]", certificate.Subject)
+	}
+
+	return nil
+}
+
+// validateCertificateChain returns an error where the PEM encoded certificate can't be verified
+// using the certificates of the PEM encoded CA bundle. Validity periods are checked separately,
+// so the chain is verified at the time the certificate became valid
+func validateCertificateChain(certificatePEM string, caBundlePEM string) error {
+	certificate, err := parseCertificatePEM(certificatePEM)
+	if err != nil {
+		return fmt.Errorf("certificate: %w", err)
+	}
+
+	caBundle, err := parseCertificatesPEM(caBundlePEM)
+	if err != nil {
+		return fmt.Errorf("ca_bundle: %w", err)
+	}
+
+	// The CA bundle needn't include a root, so each of its certificates is trusted as one
+	pool := x509.NewCertPool()
+	for _, ca := range caBundle {
+		pool.AddCert(ca)
+	}
+
+	_, err = certificate.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: pool,
+		CurrentTime:   certificate.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("ca_bundle: certificate [%s] issued by [%s] doesn't chain to the CA bundle: %w", certificate.Subject, certificate.Issuer, err)
+	}
+
+	return nil
+}
+
+// validateCertificatePEM validates that a certificate can be parsed, warning where it has expired
+// or isn't yet valid
+func validateCertificatePEM(v interface{}, k string) ([]string, []error) {
+	certificate, err := parseCertificatePEM(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+
+	now := time.Now()
+	switch {
+	case now.After(certificate.NotAfter):
+		return []string{fmt.Sprintf("%s: certificate [%s] expired at %s", k, certificate.Subject, certificate.NotAfter.UTC().Format(time.RFC3339))}, nil
+	case now.Before(certificate.NotBefore):
+		return []string{fmt.Sprintf("%s: certificate [%s] isn't valid until %s", k, certificate.Subject, certificate.NotBefore.UTC().Format(time.RFC3339))}, nil
+	}
+
+	return nil, nil
+}
+
+// parseAPIDateTime parses a date time returned by the API, which may or may not separate the
+// hours and minutes of its offset. Date times which can't be parsed are logged
+func parseAPIDateTime(ctx context.Context, dateTime connection.DateTime) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05-0700"} {
+		t, err := time.Parse(layout, dateTime.String())
+		if err == nil {
+			return t, true
+		}
+	}
+
+	tflog.Warn(ctx, "unable to parse date time returned by the API", map[string]any{
+		"date_time": dateTime.String(),
+	})
+
+	return time.Time{}, false
+}
@@ -1,15 +1,132 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/ans-group/terraform-provider-loadbalancer/loadbalancer"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
+// subcommands are run in place of serving the provider when named as the first argument
+var subcommands = map[string]func(args []string) error{
+	"generate": generate,
+	"restore":  restore,
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			if err := subcommand(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
 			return loadbalancer.Provider()
 		},
 	})
 }
+
+// generate writes configuration and import blocks for an existing cluster, authenticating with
+// the API key in ANS_API_KEY
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	clusterID := flags.Int("cluster-id", 0, "ID of the loadbalancer cluster to generate configuration for")
+	dir := flags.String("dir", ".", "Directory to write configuration to")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate -cluster-id <id> [-dir <directory>]\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if *clusterID < 1 {
+		flags.Usage()
+		return errors.New("cluster-id is required")
+	}
+
+	apiKey := os.Getenv("ANS_API_KEY")
+	if apiKey == "" {
+		return errors.New("ANS_API_KEY must be set")
+	}
+
+	return loadbalancer.Generate(context.Background(), apiKey, *clusterID, *dir)
+}
+
+// restore recreates the configuration within a snapshot, as produced by the
+// loadbalancer_cluster_snapshot data source, on a cluster
+func restore(args []string) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	clusterID := flags.Int("cluster-id", 0, "ID of the loadbalancer cluster to restore to, which must not have any listeners or target groups")
+	snapshotPath := flags.String("snapshot", "", "Path to snapshot JSON, or - to read from stdin")
+	vipMap := flags.String("vip-map", "", "Comma separated mappings of snapshot VIP IDs to target cluster VIP IDs, e.g. 1=5,2=6, required for every VIP bound by a listener")
+	autoMapVIPs := flags.Bool("auto-map-vips", false, "Match bound snapshot VIPs missing from -vip-map to the remaining target cluster VIPs in order of ID")
+	certificateDir := flags.String("certificate-dir", "", "Directory holding certificate key material named by snapshot certificate ID, as {id}.key, {id}.crt and {id}.ca-bundle")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s restore -cluster-id <id> -snapshot <path> [-vip-map <mappings>] [-auto-map-vips] [-certificate-dir <directory>]\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+
+	if *clusterID < 1 || *snapshotPath == "" {
+		flags.Usage()
+		return errors.New("cluster-id and snapshot are required")
+	}
+
+	options := loadbalancer.RestoreOptions{
+		VIPMap:         make(map[int]int),
+		AutoMapVIPs:    *autoMapVIPs,
+		CertificateDir: *certificateDir,
+	}
+
+	if *vipMap != "" {
+		for _, mapping := range strings.Split(*vipMap, ",") {
+			rawSource, rawTarget, _ := strings.Cut(mapping, "=")
+
+			source, sourceErr := strconv.Atoi(strings.TrimSpace(rawSource))
+			target, targetErr := strconv.Atoi(strings.TrimSpace(rawTarget))
+			if sourceErr != nil || targetErr != nil {
+				return fmt.Errorf("invalid VIP mapping [%s], expected {snapshot_vip_id}={vip_id}", mapping)
+			}
+
+			options.VIPMap[source] = target
+		}
+	}
+
+	var snapshot []byte
+	var err error
+	if *snapshotPath == "-" {
+		snapshot, err = io.ReadAll(os.Stdin)
+	} else {
+		snapshot, err = os.ReadFile(*snapshotPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	apiKey := os.Getenv("ANS_API_KEY")
+	if apiKey == "" {
+		return errors.New("ANS_API_KEY must be set")
+	}
+
+	warnings, err := loadbalancer.Restore(context.Background(), apiKey, snapshot, *clusterID, options)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	return err
+}